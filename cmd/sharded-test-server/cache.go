@@ -51,14 +51,13 @@ func startCacheServer(ctx context.Context, logDirPath, workingDir string, synthe
 		lineprefix.Color(color.New(color.FgHiWhite)),
 	)
 	cacheWorkingDir := filepath.Join(workingDir, ".kcp-cache")
-	cachePort := 8012
 	commandLine := kcptestingserver.Command("cache-server", "cache")
 	commandLine = append(
 		commandLine,
 		fmt.Sprintf("--root-directory=%s", cacheWorkingDir),
-		"--embedded-etcd-client-port=8010",
-		"--embedded-etcd-peer-port=8011",
-		fmt.Sprintf("--secure-port=%d", cachePort),
+		fmt.Sprintf("--embedded-etcd-client-port=%d", cacheEtcdClientPort()),
+		fmt.Sprintf("--embedded-etcd-peer-port=%d", cacheEtcdPeerPort()),
+		fmt.Sprintf("--secure-port=%d", cachePort()),
 		fmt.Sprintf("--synthetic-delay=%s", syntheticDelay.String()),
 	)
 	fmt.Fprintf(out, "running: %v\n", strings.Join(commandLine, " "))
@@ -124,7 +123,7 @@ func startCacheServer(ctx context.Context, logDirPath, workingDir string, synthe
 			cacheServerKubeConfig := clientcmdapi.Config{
 				Clusters: map[string]*clientcmdapi.Cluster{
 					"cache": {
-						Server:                   fmt.Sprintf("https://localhost:%d", cachePort),
+						Server:                   fmt.Sprintf("https://localhost:%d", cachePort()),
 						CertificateAuthorityData: cacheServerCert,
 					},
 				},
@@ -46,4 +46,6 @@ limitations under the License.
 // It will create generic files in .kcp, proxy files in .kcp-front-proxy and shard specific files in .kcp-0, .kcp-1, .kcp-2.
 // The usual .kcp/admin.kubeconfig will direct to the front-proxy. The individual shard .kcp/admin.kubeconfig will direct to
 // the shards.
+//
+// With --port-offset all listening ports are shifted by the given offset, so several instances can run side by side.
 package main
@@ -25,6 +25,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -86,7 +87,7 @@ func startFrontProxy(
 		{
 			Path: "/clusters/",
 			// TODO: support multiple shard backend servers
-			Backend:         "https://localhost:6444",
+			Backend:         fmt.Sprintf("https://localhost:%d", shardPort(0)),
 			BackendServerCA: filepath.Join(workDirPath, ".kcp/serving-ca.crt"),
 			ProxyClientCert: filepath.Join(workDirPath, ".kcp-front-proxy/requestheader.crt"),
 			ProxyClientKey:  filepath.Join(workDirPath, ".kcp-front-proxy/requestheader.key"),
@@ -139,7 +140,7 @@ func startFrontProxy(
 		fmt.Sprintf("--client-ca-file=%s", filepath.Join(workDirPath, ".kcp/client-ca.crt")),
 		fmt.Sprintf("--tls-cert-file=%s", filepath.Join(workDirPath, ".kcp-front-proxy/apiserver.crt")),
 		fmt.Sprintf("--tls-private-key-file=%s", filepath.Join(workDirPath, ".kcp-front-proxy/apiserver.key")),
-		"--secure-port=6443",
+		fmt.Sprintf("--secure-port=%d", frontProxyPort()),
 		"--v=4",
 	)
 	commandLine = append(commandLine, args...)
@@ -254,7 +255,7 @@ func scrapeMetrics(ctx context.Context, cfg *rest.Config, workDir string) error
 }
 
 func writeAdminKubeConfig(hostIP string, workDirPath string) error {
-	baseHost := "https://" + net.JoinHostPort(hostIP, "6443")
+	baseHost := "https://" + net.JoinHostPort(hostIP, strconv.Itoa(frontProxyPort()))
 
 	var kubeConfig clientcmdapi.Config
 	kubeConfig.AuthInfos = map[string]*clientcmdapi.AuthInfo{
@@ -315,7 +316,7 @@ func writeLogicalClusterAdminKubeConfig(hostIP, workDirPath string) error {
 	// The logical-cluster-admin-kubeconfig references the root shard, it's
 	// also used to access other shards directly; also see the external
 	// config below which is used for connections via the front-proxy
-	baseHost := fmt.Sprintf("https://%s", net.JoinHostPort(hostIP, "6444"))
+	baseHost := fmt.Sprintf("https://%s", net.JoinHostPort(hostIP, strconv.Itoa(shardPort(0))))
 
 	var kubeConfig clientcmdapi.Config
 	kubeConfig.AuthInfos = map[string]*clientcmdapi.AuthInfo{
@@ -344,7 +345,7 @@ func writeLogicalClusterAdminKubeConfig(hostIP, workDirPath string) error {
 
 func writeExternalLogicalClusterAdminKubeConfig(hostIP, workDirPath string) error {
 	// The external config references the front-proxy endpoint
-	baseHost := fmt.Sprintf("https://%s", net.JoinHostPort(hostIP, "6443"))
+	baseHost := fmt.Sprintf("https://%s", net.JoinHostPort(hostIP, strconv.Itoa(frontProxyPort())))
 
 	var kubeConfig clientcmdapi.Config
 	kubeConfig.AuthInfos = map[string]*clientcmdapi.AuthInfo{
@@ -23,6 +23,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -49,6 +50,7 @@ func main() {
 	numberOfShards := flag.Int("number-of-shards", 1, "The number of shards to create. The first created is assumed root.")
 	cacheSyntheticDelay := flag.Duration("cache-synthetic-delay", 0, "The duration of time the cache server will inject a delay for to all inbound requests.")
 	quiet := flag.Bool("quiet", false, "Suppress output of the subprocesses")
+	flag.IntVar(&portOffset, "port-offset", 0, "Offset added to all ports, to run several sharded test servers side by side.")
 
 	// split flags into --proxy-*, --shard-* and everything else (generic). The former are
 	// passed to the respective components.
@@ -220,25 +222,29 @@ func start(proxyFlags, shardFlags []string, logDirPath, workDirPath string, numb
 		return err
 	}
 
-	// start shards
+	// start shards, each with its own context so that it can be restarted
 	var shards []*testshard.Shard
+	var shardCancels []context.CancelFunc
 	for i := 0; i < numberOfShards; i++ {
 		shard, err := newShard(ctx, i, shardFlags, standaloneVW, servingCA, hostIP.String(), logDirPath, workDirPath, cacheServerConfigPath, clientCA)
 		if err != nil {
 			return err
 		}
-		if err := shard.Start(ctx, quiet); err != nil {
+		shardCtx, shardCancel := context.WithCancel(ctx)
+		if err := shard.Start(shardCtx, quiet); err != nil {
+			shardCancel()
 			return err
 		}
 		shards = append(shards, shard)
+		shardCancels = append(shardCancels, shardCancel)
 	}
 
 	// Start virtual-workspace servers
-	vwPort := "6444"
+	vwPort := strconv.Itoa(shardPort(0))
 	var virtualWorkspaces []*VirtualWorkspace
 	if standaloneVW {
 		// TODO: support multiple virtual workspace servers (i.e. multiple ports)
-		vwPort = "7444"
+		vwPort = virtualWorkspacePort(0)
 
 		for i := 0; i < numberOfShards; i++ {
 			vw, err := newVirtualWorkspace(ctx, i, servingCA, hostIP.String(), logDirPath, workDirPath, clientCA, cacheServerConfigPath)
@@ -263,10 +269,7 @@ func start(proxyFlags, shardFlags []string, logDirPath, workDirPath string, numb
 		if err != nil {
 			return err
 		}
-		go func(i int, terminatedCh <-chan error) {
-			err := <-terminatedCh
-			shardsErrCh <- indexErrTuple{i, err}
-		}(i, terminatedCh)
+		go superviseShard(ctx, i, s, shardCancels[i], terminatedCh, workDirPath, quiet, shardsErrCh)
 	}
 
 	// Wait for virtual workspaces to be ready
@@ -23,12 +23,14 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/klog/v2"
 
 	shard "github.com/kcp-dev/kcp/cmd/test-server/kcp"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
 	"github.com/kcp-dev/kcp/sdk/testing/third_party/library-go/crypto"
 )
 
@@ -75,13 +77,13 @@ func newShard(ctx context.Context, n int, args []string, standaloneVW bool, serv
 		args = append(args,
 			fmt.Sprintf("--shard-name=shard-%d", n),
 			fmt.Sprintf("--root-shard-kubeconfig-file=%s", filepath.Join(workDirPath, ".kcp-0/admin.kubeconfig")),
-			fmt.Sprintf("--embedded-etcd-client-port=%d", embeddedEtcdClientPort(n)),
-			fmt.Sprintf("--embedded-etcd-peer-port=%d", embeddedEtcdPeerPort(n)),
 		)
 	}
 	args = append(args,
 		/*fmt.Sprintf("--cluster-workspace-shard-name=kcp-%d", n),*/
 		fmt.Sprintf("--root-directory=%s", filepath.Join(workDirPath, fmt.Sprintf(".kcp-%d", n))),
+		fmt.Sprintf("--embedded-etcd-client-port=%d", embeddedEtcdClientPort(n)),
+		fmt.Sprintf("--embedded-etcd-peer-port=%d", embeddedEtcdPeerPort(n)),
 		fmt.Sprintf("--client-ca-file=%s", filepath.Join(workDirPath, ".kcp/client-ca.crt")),
 		fmt.Sprintf("--requestheader-client-ca-file=%s", filepath.Join(workDirPath, ".kcp/requestheader-ca.crt")),
 		"--requestheader-username-headers=X-Remote-User",
@@ -93,10 +95,10 @@ func newShard(ctx context.Context, n int, args []string, standaloneVW bool, serv
 		// TODO(sttts): remove this flag as soon as we have service account token lookup configured.
 		"--service-account-lookup=false",
 		"--audit-log-path", auditFilePath,
-		fmt.Sprintf("--shard-external-url=https://%s:%d", hostIP, 6443),
+		fmt.Sprintf("--shard-external-url=https://%s:%d", hostIP, frontProxyPort()),
 		fmt.Sprintf("--tls-cert-file=%s", filepath.Join(workDirPath, fmt.Sprintf(".kcp-%d/apiserver.crt", n))),
 		fmt.Sprintf("--tls-private-key-file=%s", filepath.Join(workDirPath, fmt.Sprintf(".kcp-%d/apiserver.key", n))),
-		fmt.Sprintf("--secure-port=%d", 6444+n),
+		fmt.Sprintf("--secure-port=%d", shardPort(n)),
 		fmt.Sprintf("--logical-cluster-admin-kubeconfig=%s", filepath.Join(workDirPath, ".kcp/logical-cluster-admin.kubeconfig")),
 		fmt.Sprintf("--external-logical-cluster-admin-kubeconfig=%s", filepath.Join(workDirPath, ".kcp/external-logical-cluster-admin.kubeconfig")),
 		fmt.Sprintf("--shard-client-cert-file=%s", shardClientCert),
@@ -121,14 +123,102 @@ func newShard(ctx context.Context, n int, args []string, standaloneVW bool, serv
 	), nil
 }
 
+// restartRequestedFile returns the path of the file that requests a restart of
+// shard n. Once it exists, the shard process is killed and started again with
+// the same arguments, and the file is removed when the shard is ready again.
+func restartRequestedFile(workDirPath string, n int) string {
+	return filepath.Join(workDirPath, fmt.Sprintf(".kcp-%d", n), "restart-requested")
+}
+
+// superviseShard reports the termination of shard n to errCh, unless it was
+// killed for a restart requested through restartRequestedFile. cancel stops
+// the running shard process.
+func superviseShard(ctx context.Context, n int, s *shard.Shard, cancel context.CancelFunc, terminatedCh <-chan error, workDirPath string, quiet bool, errCh chan<- indexErrTuple) {
+	logger := klog.FromContext(ctx).WithValues("shard", n)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-terminatedCh:
+			cancel()
+			errCh <- indexErrTuple{n, err}
+			return
+		case <-ctx.Done():
+			cancel()
+			return
+		case <-ticker.C:
+		}
+
+		restartFile := restartRequestedFile(workDirPath, n)
+		if _, err := os.Stat(restartFile); err != nil {
+			continue
+		}
+
+		logger.Info("restarting shard")
+		cancel()
+		<-terminatedCh
+
+		var shardCtx context.Context
+		shardCtx, cancel = context.WithCancel(ctx)
+		if err := s.Start(shardCtx, quiet); err != nil {
+			cancel()
+			errCh <- indexErrTuple{n, fmt.Errorf("failed to restart: %w", err)}
+			return
+		}
+		var err error
+		terminatedCh, err = s.WaitForReady(shardCtx)
+		if err != nil {
+			cancel()
+			errCh <- indexErrTuple{n, fmt.Errorf("failed to restart: %w", err)}
+			return
+		}
+		if err := os.Remove(restartFile); err != nil {
+			cancel()
+			errCh <- indexErrTuple{n, fmt.Errorf("failed to remove %s: %w", restartFile, err)}
+			return
+		}
+		logger.Info("shard restarted")
+	}
+}
+
+// portOffset is added to all ports, so that several sharded test servers can
+// run side by side.
+var portOffset int
+
+// ports returns the port layout shared with the sharded server fixture, which
+// reserves the same ports before starting the sharded-test-server.
+func ports() kcptestingserver.ShardedTestServerPorts {
+	return kcptestingserver.ShardedTestServerPorts{Offset: portOffset}
+}
+
+func frontProxyPort() int {
+	return ports().FrontProxy()
+}
+
+func shardPort(n int) int {
+	return ports().Shard(n)
+}
+
 func virtualWorkspacePort(n int) string {
-	return strconv.Itoa(7444 + n)
+	return strconv.Itoa(ports().VirtualWorkspace(n))
 }
 
 func embeddedEtcdClientPort(n int) int {
-	return 2380 + (n * 2) - 1
+	return ports().EtcdClient(n)
 }
 
 func embeddedEtcdPeerPort(n int) int {
-	return 2380 + (n * 2)
+	return ports().EtcdPeer(n)
+}
+
+func cachePort() int {
+	return ports().Cache()
+}
+
+func cacheEtcdClientPort() int {
+	return ports().CacheEtcdClient()
+}
+
+func cacheEtcdPeerPort() int {
+	return ports().CacheEtcdPeer()
 }
@@ -104,7 +104,7 @@ func newVirtualWorkspace(ctx context.Context, index int, servingCA *crypto.CA, h
 	virtualWorkspaceKubeConfig := clientcmdapi.Config{
 		Clusters: map[string]*clientcmdapi.Cluster{
 			"shard": {
-				Server:               fmt.Sprintf("https://localhost:%d", 6444+index),
+				Server:               fmt.Sprintf("https://localhost:%d", shardPort(index)),
 				CertificateAuthority: servingCAPath,
 			},
 		},
@@ -145,7 +145,7 @@ func newVirtualWorkspace(ctx context.Context, index int, servingCA *crypto.CA, h
 	var args []string
 	args = append(args,
 		fmt.Sprintf("--kubeconfig=%s", kubeconfigPath),
-		fmt.Sprintf("--shard-external-url=https://%s:%d", hostIP, 6443),
+		fmt.Sprintf("--shard-external-url=https://%s:%d", hostIP, frontProxyPort()),
 		fmt.Sprintf("--cache-kubeconfig=%s", cacheServerConfigPath),
 		fmt.Sprintf("--authentication-kubeconfig=%s", authenticationKubeconfigPath),
 		fmt.Sprintf("--client-ca-file=%s", clientCAFilePath),
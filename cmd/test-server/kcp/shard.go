@@ -27,6 +27,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/abiosoft/lineprefix"
@@ -45,6 +46,10 @@ import (
 //go:embed *.yaml
 var embeddedResources embed.FS
 
+// shutdownTimeout is how long a shard may take to shut down gracefully before
+// it is killed.
+const shutdownTimeout = 30 * time.Second
+
 type headWriter interface {
 	io.Writer
 	StopOut()
@@ -112,7 +117,12 @@ func (s *Shard) Start(ctx context.Context, quiet bool) error {
 	)
 	fmt.Fprintf(out, "running: %v\n", strings.Join(commandLine, " "))
 
-	cmd := exec.CommandContext(ctx, commandLine[0], commandLine[1:]...) //nolint:gosec
+	// not bound to ctx, the process group is stopped gracefully below instead
+	// of killing the process when ctx is done
+	cmd := exec.Command(commandLine[0], commandLine[1:]...) //nolint:gosec
+	// run in a process group of its own, so that kcp is stopped along with
+	// `go run` when the shard is stopped or restarted
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 	if err := os.MkdirAll(filepath.Dir(s.logFilePath), 0755); err != nil {
 		return err
 	}
@@ -134,18 +144,21 @@ func (s *Shard) Start(ctx context.Context, quiet bool) error {
 		return err
 	}
 
+	pgid := cmd.Process.Pid
 	go func() {
 		<-ctx.Done()
-		if err := cmd.Process.Kill(); err != nil {
-			logger.Error(err, "failed to kill process")
-		}
+		stopProcessGroup(logger, pgid)
 	}()
 
 	// Start a goroutine that will notify when the process has exited
 	terminatedCh := make(chan error, 1)
 	s.terminatedCh = terminatedCh
 	go func() {
-		terminatedCh <- cmd.Wait()
+		err := cmd.Wait()
+		// kcp can outlive `go run`, only report the termination once the
+		// whole process group is gone
+		stopProcessGroup(logger, pgid)
+		terminatedCh <- err
 	}()
 
 	// wait for admin.kubeconfig
@@ -175,6 +188,40 @@ func (s *Shard) Start(ctx context.Context, quiet bool) error {
 	return nil
 }
 
+// stopProcessGroup sends SIGTERM to the process group pgid, so that kcp shuts
+// down gracefully and flushes audit logs and profiles. If the group has not
+// exited after shutdownTimeout, it is killed.
+func stopProcessGroup(logger klog.Logger, pgid int) {
+	if err := syscall.Kill(-pgid, syscall.SIGTERM); err != nil {
+		if !errors.Is(err, syscall.ESRCH) {
+			logger.Error(err, "failed to terminate process group")
+		}
+		return
+	}
+	if waitForProcessGroup(pgid, shutdownTimeout) {
+		return
+	}
+	logger.Info("Process group did not terminate in time, killing it", "timeout", shutdownTimeout)
+	if err := syscall.Kill(-pgid, syscall.SIGKILL); err != nil && !errors.Is(err, syscall.ESRCH) {
+		logger.Error(err, "failed to kill process group")
+		return
+	}
+	waitForProcessGroup(pgid, shutdownTimeout)
+}
+
+// waitForProcessGroup waits up to timeout for all processes of the group pgid
+// to exit and reports whether they did.
+func waitForProcessGroup(pgid int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if err := syscall.Kill(-pgid, 0); errors.Is(err, syscall.ESRCH) {
+			return true
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return false
+}
+
 func (s *Shard) WaitForReady(ctx context.Context) (<-chan error, error) {
 	// wait for readiness
 	logger := klog.FromContext(ctx)
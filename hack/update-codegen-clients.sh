@@ -37,9 +37,32 @@ chmod +x "${CODEGEN_PKG}"/generate-internal-groups.sh
 
 source "${CODEGEN_PKG}/kube_codegen.sh"
 
+go install "${OPENAPI_PKG}"/cmd/openapi-gen
+
+"$GOPATH"/bin/openapi-gen \
+  --go-header-file ./hack/../hack/boilerplate/boilerplate.generatego.txt \
+  --output-pkg github.com/kcp-dev/kcp/pkg/openapi \
+  --output-file zz_generated.openapi.go \
+  --output-dir "${SCRIPT_ROOT}/pkg/openapi" \
+  github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1 \
+  github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1 \
+  github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1 \
+  github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2 \
+  github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1 \
+  github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1 \
+  k8s.io/apimachinery/pkg/apis/meta/v1 \
+  k8s.io/apimachinery/pkg/runtime \
+  k8s.io/apimachinery/pkg/version
+
+# the schema is needed for the Extract functions of the apply configurations
+OPENAPI_SCHEMA="$(mktemp)"
+trap 'rm -f "${OPENAPI_SCHEMA}"' EXIT
+go run ./pkg/openapi/cmd/models-schema > "${OPENAPI_SCHEMA}"
+
 "$GOPATH"/bin/applyconfiguration-gen \
   --go-header-file ./hack/../hack/boilerplate/boilerplate.generatego.txt \
   --output-pkg github.com/kcp-dev/kcp/sdk/client/applyconfiguration \
+  --openapi-schema "${OPENAPI_SCHEMA}" \
   --output-dir "${SCRIPT_ROOT}/sdk/client/applyconfiguration" \
   github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1 \
   github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1 \
@@ -109,20 +132,3 @@ ${CODE_GENERATOR} \
   "paths=./..." \
   "output:dir=./../client"
 popd
-
-go install "${OPENAPI_PKG}"/cmd/openapi-gen
-
-"$GOPATH"/bin/openapi-gen \
-  --go-header-file ./hack/../hack/boilerplate/boilerplate.generatego.txt \
-  --output-pkg github.com/kcp-dev/kcp/pkg/openapi \
-  --output-file zz_generated.openapi.go \
-  --output-dir "${SCRIPT_ROOT}/pkg/openapi" \
-  github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1 \
-  github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1 \
-  github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1 \
-  github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2 \
-  github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1 \
-  github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/apis/conditions/v1alpha1 \
-  k8s.io/apimachinery/pkg/apis/meta/v1 \
-  k8s.io/apimachinery/pkg/runtime \
-  k8s.io/apimachinery/pkg/version
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// models-schema prints the OpenAPI v2 schema of the kcp API types, for the
+// generation of the Extract functions of the apply configurations.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/common"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	generatedopenapi "k8s.io/kubernetes/pkg/generated/openapi"
+
+	"github.com/kcp-dev/kcp/pkg/openapi"
+)
+
+func main() {
+	if err := output(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func output() error {
+	refFunc := func(name string) spec.Ref {
+		return spec.MustCreateRef(fmt.Sprintf("#/definitions/%s", friendlyName(name)))
+	}
+	// the kcp types reference kube types, e.g. core/v1 and apiextensions, so
+	// their definitions are included as well
+	defs := generatedopenapi.GetOpenAPIDefinitions(refFunc)
+	for k, v := range openapi.GetOpenAPIDefinitions(refFunc) {
+		defs[k] = v
+	}
+	schemaDefs := make(map[string]spec.Schema, len(defs))
+	for k, v := range defs {
+		// replace the top-level schema with the v2 one if embedded, so that
+		// the output is always OpenAPI v2
+		if schema, ok := v.Schema.Extensions[common.ExtensionV2Schema]; ok {
+			if v2Schema, isOpenAPISchema := schema.(spec.Schema); isOpenAPISchema {
+				schemaDefs[friendlyName(k)] = v2Schema
+				continue
+			}
+		}
+		schemaDefs[friendlyName(k)] = v.Schema
+	}
+	data, err := json.Marshal(&spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Definitions: schemaDefs,
+			Info: &spec.Info{
+				InfoProps: spec.InfoProps{
+					Title:   "kcp",
+					Version: "unversioned",
+				},
+			},
+			Swagger: "2.0",
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing api definitions: %w", err)
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+// friendlyName is the same as in k8s.io/apiserver/pkg/endpoints/openapi, it
+// reverses the domain of the package, e.g. io.k8s.apimachinery for
+// k8s.io/apimachinery.
+func friendlyName(name string) string {
+	nameParts := strings.Split(name, "/")
+	if len(nameParts) > 0 && strings.Contains(nameParts[0], ".") {
+		parts := strings.Split(nameParts[0], ".")
+		for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+			parts[i], parts[j] = parts[j], parts[i]
+		}
+		nameParts[0] = strings.Join(parts, ".")
+	}
+	return strings.Join(nameParts, ".")
+}
@@ -191,6 +191,10 @@ func NewConfig(ctx context.Context, opts kcpserveroptions.CompletedOptions) (*Co
 		if err != nil {
 			return nil, err
 		}
+		if opts.Extra.EmbeddedEtcdAutoCompactionMode != "" {
+			c.EmbeddedEtcd.AutoCompactionMode = opts.Extra.EmbeddedEtcdAutoCompactionMode
+			c.EmbeddedEtcd.AutoCompactionRetention = opts.Extra.EmbeddedEtcdAutoCompactionRetention
+		}
 	}
 
 	var err error
@@ -396,6 +400,10 @@ func NewConfig(ctx context.Context, opts kcpserveroptions.CompletedOptions) (*Co
 	// to give handlers below one mux.Handle func to call.
 	c.preHandlerChainMux = &handlerChainMuxes{}
 	c.GenericConfig.BuildHandlerChainFunc = func(apiHandler http.Handler, genericConfig *genericapiserver.Config) (secure http.Handler) {
+		if opts.Extra.ServerTiming {
+			// innermost, to see the latency trackers and to measure all filters
+			apiHandler = kcpfilters.WithServerTiming(apiHandler)
+		}
 		apiHandler = openapiv3.WithOpenAPIv3(apiHandler, c.openAPIv3ServiceCache) // will be initialized further down after apiextensions-apiserver
 		apiHandler = kcpfilters.WithWildcardListWatchGuard(apiHandler)
 		apiHandler = kcpfilters.WithResourceIdentity(apiHandler)
@@ -478,6 +486,9 @@ func NewConfig(ctx context.Context, opts kcpserveroptions.CompletedOptions) (*Co
 		if err != nil {
 			panic(err) // shouldn't happen due to flag validation
 		}
+		if opts.Extra.DiscoveryCacheMaxAge > 0 {
+			apiHandler = kcpfilters.WithDiscoveryCacheControl(apiHandler, opts.Extra.DiscoveryCacheMaxAge)
+		}
 		apiHandler = kcpfilters.WithInClusterServiceAccountRequestRewrite(apiHandler)
 		apiHandler = kcpfilters.WithAcceptHeader(apiHandler)
 		apiHandler = kcpfilters.WithUserAgent(apiHandler)
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// reDiscoveryPath matches the paths of discovery and OpenAPI documents, without
+// a /clusters/<path> prefix.
+var reDiscoveryPath = regexp.MustCompile(`^/(api(/[^/]+)?|apis(/[^/]+){0,2}|openapi/v2|openapi/v3(/.*)?)/?$`)
+
+// WithDiscoveryCacheControl makes successful GET responses of discovery and
+// OpenAPI endpoints cacheable for maxAge by setting Cache-Control to
+// "private, max-age=<seconds>", and an ETag over the body, unless the endpoint
+// sets them itself. Requests with a matching If-None-Match header get a 304 Not
+// Modified. Responses are buffered to compute the ETag.
+func WithDiscoveryCacheControl(handler http.Handler, maxAge time.Duration) http.Handler {
+	cacheControl := fmt.Sprintf("private, max-age=%d", int64(maxAge/time.Second))
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet || !isDiscoveryPath(req.URL.Path) {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		rw := &bufferedResponseWriter{header: http.Header{}}
+		handler.ServeHTTP(rw, req)
+
+		header := w.Header()
+		for k, v := range rw.header {
+			header[k] = v
+		}
+		if rw.code != http.StatusOK && rw.code != 0 {
+			w.WriteHeader(rw.code)
+			w.Write(rw.body.Bytes()) //nolint:errcheck
+			return
+		}
+
+		if header.Get("Cache-Control") == "" {
+			header.Set("Cache-Control", cacheControl)
+		}
+		etag := header.Get("ETag")
+		if etag == "" {
+			sum := sha256.Sum256(rw.body.Bytes())
+			etag = `"` + hex.EncodeToString(sum[:]) + `"`
+			header.Set("ETag", etag)
+			header.Add("Vary", "Accept")
+		}
+		if ifNoneMatch := req.Header.Get("If-None-Match"); ifNoneMatch != "" && ifNoneMatch == etag {
+			header.Del("Content-Length")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(rw.body.Bytes()) //nolint:errcheck
+	})
+}
+
+func isDiscoveryPath(path string) bool {
+	if rest, ok := strings.CutPrefix(path, "/clusters/"); ok {
+		i := strings.Index(rest, "/")
+		if i == -1 {
+			return false
+		}
+		path = rest[i:]
+	}
+	return reDiscoveryPath.MatchString(path)
+}
+
+// bufferedResponseWriter records a response to be written later.
+type bufferedResponseWriter struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedResponseWriter) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+	}
+}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	return w.body.Write(data)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDiscoveryCacheControl(t *testing.T) {
+	handler := WithDiscoveryCacheControl(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/apis/forbidden":
+			http.Error(w, "forbidden", http.StatusForbidden)
+		case "/openapi/v3/apis/apps/v1":
+			w.Header().Set("Cache-Control", "public, immutable")
+			w.Header().Set("ETag", `"openapi"`)
+			w.Write([]byte(`{}`)) //nolint:errcheck
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"kind":"APIGroupList"}`)) //nolint:errcheck
+		}
+	}), 90*time.Second)
+
+	serve := func(method, path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+		return rw
+	}
+
+	tests := map[string]struct {
+		method       string
+		path         string
+		code         int
+		cacheControl string
+		etag         bool
+	}{
+		"legacy discovery":         {http.MethodGet, "/api", http.StatusOK, "private, max-age=90", true},
+		"group version discovery":  {http.MethodGet, "/apis/apps/v1", http.StatusOK, "private, max-age=90", true},
+		"discovery of a workspace": {http.MethodGet, "/clusters/root:org/apis", http.StatusOK, "private, max-age=90", true},
+		"openapi v2":               {http.MethodGet, "/openapi/v2", http.StatusOK, "private, max-age=90", true},
+		"openapi own headers":      {http.MethodGet, "/openapi/v3/apis/apps/v1", http.StatusOK, "public, immutable", true},
+		"resource list":            {http.MethodGet, "/apis/apps/v1/deployments", http.StatusOK, "", false},
+		"post":                     {http.MethodPost, "/apis", http.StatusOK, "", false},
+		"error":                    {http.MethodGet, "/apis/forbidden", http.StatusForbidden, "", false},
+		"cluster without path":     {http.MethodGet, "/clusters/root", http.StatusOK, "", false},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rw := serve(tc.method, tc.path, nil)
+			require.Equal(t, tc.code, rw.Code)
+			require.Equal(t, tc.cacheControl, rw.Header().Get("Cache-Control"))
+			require.Equal(t, tc.etag, rw.Header().Get("ETag") != "")
+		})
+	}
+
+	t.Run("not modified", func(t *testing.T) {
+		rw := serve(http.MethodGet, "/apis", nil)
+		etag := rw.Header().Get("ETag")
+		require.NotEmpty(t, rw.Body.String())
+
+		rw = serve(http.MethodGet, "/apis", http.Header{"If-None-Match": []string{etag}})
+		require.Equal(t, http.StatusNotModified, rw.Code)
+		require.Empty(t, rw.Body.String())
+		require.Equal(t, etag, rw.Header().Get("ETag"))
+
+		rw = serve(http.MethodGet, "/apis", http.Header{"If-None-Match": []string{`"stale"`}})
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, `{"kind":"APIGroupList"}`, rw.Body.String())
+	})
+}
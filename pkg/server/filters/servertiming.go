@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/endpoints/responsewriter"
+)
+
+// WithServerTiming adds a Server-Timing header to every response with the time
+// spent in the phases of the request, in milliseconds:
+//
+//   - filters: from receiving the request until reaching the handler, i.e. in
+//     authentication, authorization and priority and fairness,
+//   - apf: queued by priority and fairness,
+//   - mutating-webhooks and validating-webhooks: calling admission webhooks,
+//   - storage: calling etcd,
+//   - decode: decoding objects read from etcd,
+//   - total: from receiving the request until writing the response header.
+//
+// Phases that took no time are omitted. The timings come from the latency
+// trackers the generic apiserver handler chain adds to the request context.
+func WithServerTiming(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		received, ok := request.ReceivedTimestampFrom(req.Context())
+		if !ok {
+			received = time.Now()
+		}
+		rw := &serverTimingResponseWriter{
+			ResponseWriter: w,
+			req:            req,
+			received:       received,
+			filters:        time.Since(received),
+		}
+		handler.ServeHTTP(responsewriter.WrapForHTTP1Or2(rw), req)
+	})
+}
+
+type serverTimingResponseWriter struct {
+	http.ResponseWriter
+
+	req         *http.Request
+	received    time.Time
+	filters     time.Duration
+	wroteHeader bool
+}
+
+var _ responsewriter.UserProvidedDecorator = &serverTimingResponseWriter{}
+
+func (w *serverTimingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *serverTimingResponseWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.Header().Set("Server-Timing", w.serverTiming())
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *serverTimingResponseWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *serverTimingResponseWriter) serverTiming() string {
+	var metrics []string
+	add := func(name string, d time.Duration) {
+		if d > 0 {
+			metrics = append(metrics, fmt.Sprintf("%s;dur=%.3f", name, float64(d)/float64(time.Millisecond)))
+		}
+	}
+
+	add("filters", w.filters)
+	if trackers, ok := request.LatencyTrackersFrom(w.req.Context()); ok {
+		add("apf", trackers.APFQueueWaitTracker.GetLatency())
+		add("mutating-webhooks", trackers.MutatingWebhookTracker.GetLatency())
+		add("validating-webhooks", trackers.ValidatingWebhookTracker.GetLatency())
+		add("storage", trackers.StorageTracker.GetLatency())
+		add("decode", trackers.DecodeTracker.GetLatency())
+	}
+	add("total", time.Since(w.received))
+	return strings.Join(metrics, ", ")
+}
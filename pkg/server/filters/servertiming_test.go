@@ -0,0 +1,59 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestWithServerTiming(t *testing.T) {
+	handler := WithServerTiming(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		request.TrackStorageLatency(req.Context(), 5*time.Millisecond)
+		w.Write([]byte(`{}`)) //nolint:errcheck
+	}))
+
+	tests := map[string]struct {
+		trackers bool
+		expected string
+	}{
+		"with latency trackers":    {true, `^filters;dur=[0-9.]+, storage;dur=5\.000, total;dur=[0-9.]+$`},
+		"without latency trackers": {false, `^filters;dur=[0-9.]+, total;dur=[0-9.]+$`},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/default/configmaps", nil)
+			ctx := request.WithReceivedTimestamp(req.Context(), time.Now().Add(-time.Millisecond))
+			if tc.trackers {
+				ctx = request.WithLatencyTrackers(ctx)
+			}
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req.WithContext(ctx))
+
+			require.Equal(t, http.StatusOK, rw.Code)
+			require.Equal(t, `{}`, rw.Body.String())
+			require.Regexp(t, regexp.MustCompile(tc.expected), rw.Header().Get("Server-Timing"))
+		})
+	}
+}
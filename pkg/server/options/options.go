@@ -21,10 +21,12 @@ import (
 	"fmt"
 	"net"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
+	"go.etcd.io/etcd/server/v3/embed"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 	genericapiserveroptions "k8s.io/apiserver/pkg/server/options"
@@ -63,11 +65,15 @@ type ExtraOptions struct {
 	ShardClientKeyFile                    string
 	ShardVirtualWorkspaceCAFile           string
 	DiscoveryPollInterval                 time.Duration
+	DiscoveryCacheMaxAge                  time.Duration
+	ServerTiming                          bool
 	ExperimentalBindFreePort              bool
 	LogicalClusterAdminKubeconfig         string
 	ExternalLogicalClusterAdminKubeconfig string
 	ConversionCELTransformationTimeout    time.Duration
 	BatteriesIncluded                     []string
+	EmbeddedEtcdAutoCompactionMode        string
+	EmbeddedEtcdAutoCompactionRetention   string
 	// DEVELOPMENT ONLY. AdditionalMappingsFile is the path to a file that contains additional mappings
 	// for the mini-front-proxy to use. The file should be in the format of the
 	// --miniproxy-mapping-file flag of the front-proxy. Do NOT expose this flag to users via main server options.
@@ -146,6 +152,8 @@ func (o *Options) AddFlags(fss *cliflag.NamedFlagSets) {
 	etcdServers.Usage += " By default an embedded etcd server is started."
 
 	o.EmbeddedEtcd.AddFlags(fss.FlagSet("Embedded etcd"))
+	fss.FlagSet("Embedded etcd").StringVar(&o.Extra.EmbeddedEtcdAutoCompactionMode, "embedded-etcd-auto-compaction-mode", o.Extra.EmbeddedEtcdAutoCompactionMode, "Auto-compaction mode of the embedded etcd, 'periodic' or 'revision'. Disabled if empty.")
+	fss.FlagSet("Embedded etcd").StringVar(&o.Extra.EmbeddedEtcdAutoCompactionRetention, "embedded-etcd-auto-compaction-retention", o.Extra.EmbeddedEtcdAutoCompactionRetention, "History retention of the embedded etcd auto-compaction: a duration, or a number of hours, for 'periodic' mode, and a number of revisions for 'revision' mode.")
 	o.Controllers.AddFlags(fss.FlagSet("KCP Controllers"))
 	o.Authorization.AddFlags(fss.FlagSet("KCP Authorization"))
 	o.AdminAuthentication.AddFlags(fss.FlagSet("KCP Authentication"))
@@ -170,6 +178,8 @@ func (o *Options) AddFlags(fss *cliflag.NamedFlagSets) {
 	fs.BoolVar(&o.Extra.ExperimentalBindFreePort, "experimental-bind-free-port", o.Extra.ExperimentalBindFreePort, "Bind to a free port. --secure-port must be 0. Use the admin.kubeconfig to extract the chosen port.")
 	fs.MarkHidden("experimental-bind-free-port") //nolint:errcheck
 
+	fs.DurationVar(&o.Extra.DiscoveryCacheMaxAge, "discovery-cache-max-age", o.Extra.DiscoveryCacheMaxAge, "If set, discovery and OpenAPI responses carry a Cache-Control max-age of this duration and an ETag, for caching proxies in front of kcp. Disabled if zero.")
+	fs.BoolVar(&o.Extra.ServerTiming, "server-timing", o.Extra.ServerTiming, "If true, responses carry a Server-Timing header with the time spent in filters, admission webhooks and storage, for debugging latency.")
 	fs.DurationVar(&o.Extra.ConversionCELTransformationTimeout, "conversion-cel-transformation-timeout", o.Extra.ConversionCELTransformationTimeout, "Maximum amount of time that CEL transformations may take per object conversion.")
 
 	fs.StringSliceVar(&o.Extra.BatteriesIncluded, "batteries-included", o.Extra.BatteriesIncluded, fmt.Sprintf(
@@ -231,6 +241,29 @@ func (o *CompletedOptions) Validate() []error {
 		errs = append(errs, fmt.Errorf("battery %s enabled which requires %s as well", batteries.User, batteries.Admin))
 	}
 
+	switch o.Extra.EmbeddedEtcdAutoCompactionMode {
+	case "":
+		if o.Extra.EmbeddedEtcdAutoCompactionRetention != "" {
+			errs = append(errs, fmt.Errorf("--embedded-etcd-auto-compaction-mode is required if --embedded-etcd-auto-compaction-retention is set"))
+		}
+	case embed.CompactorModePeriodic:
+		if _, err := time.ParseDuration(o.Extra.EmbeddedEtcdAutoCompactionRetention); err != nil {
+			if hours, err := strconv.Atoi(o.Extra.EmbeddedEtcdAutoCompactionRetention); err != nil || hours < 0 {
+				errs = append(errs, fmt.Errorf("--embedded-etcd-auto-compaction-retention must be a duration or a number of hours in periodic mode, got %q", o.Extra.EmbeddedEtcdAutoCompactionRetention))
+			}
+		}
+	case embed.CompactorModeRevision:
+		if revs, err := strconv.ParseInt(o.Extra.EmbeddedEtcdAutoCompactionRetention, 10, 64); err != nil || revs < 0 {
+			errs = append(errs, fmt.Errorf("--embedded-etcd-auto-compaction-retention must be a number of revisions in revision mode, got %q", o.Extra.EmbeddedEtcdAutoCompactionRetention))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("--embedded-etcd-auto-compaction-mode must be %q or %q, got %q", embed.CompactorModePeriodic, embed.CompactorModeRevision, o.Extra.EmbeddedEtcdAutoCompactionMode))
+	}
+
+	if o.Extra.DiscoveryCacheMaxAge < 0 {
+		errs = append(errs, fmt.Errorf("--discovery-cache-max-age must not be negative"))
+	}
+
 	if o.Extra.LogicalClusterAdminKubeconfig != "" && o.Extra.ShardExternalURL == "" {
 		errs = append(errs, fmt.Errorf("--shard-external-url is required if --logical-cluster-admin-kubeconfig is set"))
 	}
@@ -19,9 +19,12 @@ limitations under the License.
 package v1alpha1
 
 import (
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	internal "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/internal"
 	v1 "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/meta/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
+	managedfields "k8s.io/apimachinery/pkg/util/managedfields"
 )
 
 // APIExportEndpointSliceApplyConfiguration represents a declarative configuration of the APIExportEndpointSlice type for use
@@ -43,6 +46,41 @@ func APIExportEndpointSlice(name string) *APIExportEndpointSliceApplyConfigurati
 	return b
 }
 
+// ExtractAPIExportEndpointSlice extracts the applied configuration owned by fieldManager from
+// aPIExportEndpointSlice. If no managedFields are found in aPIExportEndpointSlice for fieldManager, a
+// APIExportEndpointSliceApplyConfiguration is returned with only the Name, Namespace (if applicable),
+// APIVersion and Kind populated. It is possible that no managed fields were found for because other
+// field managers have taken ownership of all the fields previously owned by fieldManager, or because
+// the fieldManager never owned fields any fields.
+// aPIExportEndpointSlice must be a unmodified APIExportEndpointSlice API object that was retrieved from the Kubernetes API.
+// ExtractAPIExportEndpointSlice provides a way to perform a extract/modify-in-place/apply workflow.
+// Note that an extracted apply configuration will contain fewer fields than what the fieldManager previously
+// applied if another fieldManager has updated or force applied any of the previously applied fields.
+// Experimental!
+func ExtractAPIExportEndpointSlice(aPIExportEndpointSlice *apisv1alpha1.APIExportEndpointSlice, fieldManager string) (*APIExportEndpointSliceApplyConfiguration, error) {
+	return extractAPIExportEndpointSlice(aPIExportEndpointSlice, fieldManager, "")
+}
+
+// ExtractAPIExportEndpointSliceStatus is the same as ExtractAPIExportEndpointSlice except
+// that it extracts the status subresource applied configuration.
+// Experimental!
+func ExtractAPIExportEndpointSliceStatus(aPIExportEndpointSlice *apisv1alpha1.APIExportEndpointSlice, fieldManager string) (*APIExportEndpointSliceApplyConfiguration, error) {
+	return extractAPIExportEndpointSlice(aPIExportEndpointSlice, fieldManager, "status")
+}
+
+func extractAPIExportEndpointSlice(aPIExportEndpointSlice *apisv1alpha1.APIExportEndpointSlice, fieldManager string, subresource string) (*APIExportEndpointSliceApplyConfiguration, error) {
+	b := &APIExportEndpointSliceApplyConfiguration{}
+	err := managedfields.ExtractInto(aPIExportEndpointSlice, internal.Parser().Type("com.github.kcp-dev.kcp.sdk.apis.apis.v1alpha1.APIExportEndpointSlice"), fieldManager, b, subresource)
+	if err != nil {
+		return nil, err
+	}
+	b.WithName(aPIExportEndpointSlice.Name)
+
+	b.WithKind("APIExportEndpointSlice")
+	b.WithAPIVersion("apis.kcp.io/v1alpha1")
+	return b, nil
+}
+
 // WithKind sets the Kind field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Kind field is set to the value of the last call.
@@ -19,9 +19,12 @@ limitations under the License.
 package v1alpha1
 
 import (
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	internal "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/internal"
 	v1 "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/meta/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
+	managedfields "k8s.io/apimachinery/pkg/util/managedfields"
 )
 
 // APIResourceSchemaApplyConfiguration represents a declarative configuration of the APIResourceSchema type for use
@@ -42,6 +45,41 @@ func APIResourceSchema(name string) *APIResourceSchemaApplyConfiguration {
 	return b
 }
 
+// ExtractAPIResourceSchema extracts the applied configuration owned by fieldManager from
+// aPIResourceSchema. If no managedFields are found in aPIResourceSchema for fieldManager, a
+// APIResourceSchemaApplyConfiguration is returned with only the Name, Namespace (if applicable),
+// APIVersion and Kind populated. It is possible that no managed fields were found for because other
+// field managers have taken ownership of all the fields previously owned by fieldManager, or because
+// the fieldManager never owned fields any fields.
+// aPIResourceSchema must be a unmodified APIResourceSchema API object that was retrieved from the Kubernetes API.
+// ExtractAPIResourceSchema provides a way to perform a extract/modify-in-place/apply workflow.
+// Note that an extracted apply configuration will contain fewer fields than what the fieldManager previously
+// applied if another fieldManager has updated or force applied any of the previously applied fields.
+// Experimental!
+func ExtractAPIResourceSchema(aPIResourceSchema *apisv1alpha1.APIResourceSchema, fieldManager string) (*APIResourceSchemaApplyConfiguration, error) {
+	return extractAPIResourceSchema(aPIResourceSchema, fieldManager, "")
+}
+
+// ExtractAPIResourceSchemaStatus is the same as ExtractAPIResourceSchema except
+// that it extracts the status subresource applied configuration.
+// Experimental!
+func ExtractAPIResourceSchemaStatus(aPIResourceSchema *apisv1alpha1.APIResourceSchema, fieldManager string) (*APIResourceSchemaApplyConfiguration, error) {
+	return extractAPIResourceSchema(aPIResourceSchema, fieldManager, "status")
+}
+
+func extractAPIResourceSchema(aPIResourceSchema *apisv1alpha1.APIResourceSchema, fieldManager string, subresource string) (*APIResourceSchemaApplyConfiguration, error) {
+	b := &APIResourceSchemaApplyConfiguration{}
+	err := managedfields.ExtractInto(aPIResourceSchema, internal.Parser().Type("com.github.kcp-dev.kcp.sdk.apis.apis.v1alpha1.APIResourceSchema"), fieldManager, b, subresource)
+	if err != nil {
+		return nil, err
+	}
+	b.WithName(aPIResourceSchema.Name)
+
+	b.WithKind("APIResourceSchema")
+	b.WithAPIVersion("apis.kcp.io/v1alpha1")
+	return b, nil
+}
+
 // WithKind sets the Kind field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Kind field is set to the value of the last call.
@@ -19,9 +19,12 @@ limitations under the License.
 package v1alpha2
 
 import (
+	apisv1alpha2 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2"
+	internal "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/internal"
 	v1 "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/meta/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
+	managedfields "k8s.io/apimachinery/pkg/util/managedfields"
 )
 
 // APIExportApplyConfiguration represents a declarative configuration of the APIExport type for use
@@ -43,6 +46,41 @@ func APIExport(name string) *APIExportApplyConfiguration {
 	return b
 }
 
+// ExtractAPIExport extracts the applied configuration owned by fieldManager from
+// aPIExport. If no managedFields are found in aPIExport for fieldManager, a
+// APIExportApplyConfiguration is returned with only the Name, Namespace (if applicable),
+// APIVersion and Kind populated. It is possible that no managed fields were found for because other
+// field managers have taken ownership of all the fields previously owned by fieldManager, or because
+// the fieldManager never owned fields any fields.
+// aPIExport must be a unmodified APIExport API object that was retrieved from the Kubernetes API.
+// ExtractAPIExport provides a way to perform a extract/modify-in-place/apply workflow.
+// Note that an extracted apply configuration will contain fewer fields than what the fieldManager previously
+// applied if another fieldManager has updated or force applied any of the previously applied fields.
+// Experimental!
+func ExtractAPIExport(aPIExport *apisv1alpha2.APIExport, fieldManager string) (*APIExportApplyConfiguration, error) {
+	return extractAPIExport(aPIExport, fieldManager, "")
+}
+
+// ExtractAPIExportStatus is the same as ExtractAPIExport except
+// that it extracts the status subresource applied configuration.
+// Experimental!
+func ExtractAPIExportStatus(aPIExport *apisv1alpha2.APIExport, fieldManager string) (*APIExportApplyConfiguration, error) {
+	return extractAPIExport(aPIExport, fieldManager, "status")
+}
+
+func extractAPIExport(aPIExport *apisv1alpha2.APIExport, fieldManager string, subresource string) (*APIExportApplyConfiguration, error) {
+	b := &APIExportApplyConfiguration{}
+	err := managedfields.ExtractInto(aPIExport, internal.Parser().Type("com.github.kcp-dev.kcp.sdk.apis.apis.v1alpha2.APIExport"), fieldManager, b, subresource)
+	if err != nil {
+		return nil, err
+	}
+	b.WithName(aPIExport.Name)
+
+	b.WithKind("APIExport")
+	b.WithAPIVersion("apis.kcp.io/v1alpha2")
+	return b, nil
+}
+
 // WithKind sets the Kind field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Kind field is set to the value of the last call.
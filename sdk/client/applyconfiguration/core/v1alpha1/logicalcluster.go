@@ -19,9 +19,12 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	internal "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/internal"
 	v1 "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/meta/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
+	managedfields "k8s.io/apimachinery/pkg/util/managedfields"
 )
 
 // LogicalClusterApplyConfiguration represents a declarative configuration of the LogicalCluster type for use
@@ -43,6 +46,41 @@ func LogicalCluster(name string) *LogicalClusterApplyConfiguration {
 	return b
 }
 
+// ExtractLogicalCluster extracts the applied configuration owned by fieldManager from
+// logicalCluster. If no managedFields are found in logicalCluster for fieldManager, a
+// LogicalClusterApplyConfiguration is returned with only the Name, Namespace (if applicable),
+// APIVersion and Kind populated. It is possible that no managed fields were found for because other
+// field managers have taken ownership of all the fields previously owned by fieldManager, or because
+// the fieldManager never owned fields any fields.
+// logicalCluster must be a unmodified LogicalCluster API object that was retrieved from the Kubernetes API.
+// ExtractLogicalCluster provides a way to perform a extract/modify-in-place/apply workflow.
+// Note that an extracted apply configuration will contain fewer fields than what the fieldManager previously
+// applied if another fieldManager has updated or force applied any of the previously applied fields.
+// Experimental!
+func ExtractLogicalCluster(logicalCluster *corev1alpha1.LogicalCluster, fieldManager string) (*LogicalClusterApplyConfiguration, error) {
+	return extractLogicalCluster(logicalCluster, fieldManager, "")
+}
+
+// ExtractLogicalClusterStatus is the same as ExtractLogicalCluster except
+// that it extracts the status subresource applied configuration.
+// Experimental!
+func ExtractLogicalClusterStatus(logicalCluster *corev1alpha1.LogicalCluster, fieldManager string) (*LogicalClusterApplyConfiguration, error) {
+	return extractLogicalCluster(logicalCluster, fieldManager, "status")
+}
+
+func extractLogicalCluster(logicalCluster *corev1alpha1.LogicalCluster, fieldManager string, subresource string) (*LogicalClusterApplyConfiguration, error) {
+	b := &LogicalClusterApplyConfiguration{}
+	err := managedfields.ExtractInto(logicalCluster, internal.Parser().Type("com.github.kcp-dev.kcp.sdk.apis.core.v1alpha1.LogicalCluster"), fieldManager, b, subresource)
+	if err != nil {
+		return nil, err
+	}
+	b.WithName(logicalCluster.Name)
+
+	b.WithKind("LogicalCluster")
+	b.WithAPIVersion("core.kcp.io/v1alpha1")
+	return b, nil
+}
+
 // WithKind sets the Kind field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Kind field is set to the value of the last call.
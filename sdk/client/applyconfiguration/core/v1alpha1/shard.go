@@ -19,9 +19,12 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	internal "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/internal"
 	v1 "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/meta/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
+	managedfields "k8s.io/apimachinery/pkg/util/managedfields"
 )
 
 // ShardApplyConfiguration represents a declarative configuration of the Shard type for use
@@ -43,6 +46,41 @@ func Shard(name string) *ShardApplyConfiguration {
 	return b
 }
 
+// ExtractShard extracts the applied configuration owned by fieldManager from
+// shard. If no managedFields are found in shard for fieldManager, a
+// ShardApplyConfiguration is returned with only the Name, Namespace (if applicable),
+// APIVersion and Kind populated. It is possible that no managed fields were found for because other
+// field managers have taken ownership of all the fields previously owned by fieldManager, or because
+// the fieldManager never owned fields any fields.
+// shard must be a unmodified Shard API object that was retrieved from the Kubernetes API.
+// ExtractShard provides a way to perform a extract/modify-in-place/apply workflow.
+// Note that an extracted apply configuration will contain fewer fields than what the fieldManager previously
+// applied if another fieldManager has updated or force applied any of the previously applied fields.
+// Experimental!
+func ExtractShard(shard *corev1alpha1.Shard, fieldManager string) (*ShardApplyConfiguration, error) {
+	return extractShard(shard, fieldManager, "")
+}
+
+// ExtractShardStatus is the same as ExtractShard except
+// that it extracts the status subresource applied configuration.
+// Experimental!
+func ExtractShardStatus(shard *corev1alpha1.Shard, fieldManager string) (*ShardApplyConfiguration, error) {
+	return extractShard(shard, fieldManager, "status")
+}
+
+func extractShard(shard *corev1alpha1.Shard, fieldManager string, subresource string) (*ShardApplyConfiguration, error) {
+	b := &ShardApplyConfiguration{}
+	err := managedfields.ExtractInto(shard, internal.Parser().Type("com.github.kcp-dev.kcp.sdk.apis.core.v1alpha1.Shard"), fieldManager, b, subresource)
+	if err != nil {
+		return nil, err
+	}
+	b.WithName(shard.Name)
+
+	b.WithKind("Shard")
+	b.WithAPIVersion("core.kcp.io/v1alpha1")
+	return b, nil
+}
+
 // WithKind sets the Kind field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Kind field is set to the value of the last call.
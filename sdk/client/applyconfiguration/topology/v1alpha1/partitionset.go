@@ -19,9 +19,12 @@ limitations under the License.
 package v1alpha1
 
 import (
+	topologyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1"
+	internal "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/internal"
 	v1 "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/meta/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
+	managedfields "k8s.io/apimachinery/pkg/util/managedfields"
 )
 
 // PartitionSetApplyConfiguration represents a declarative configuration of the PartitionSet type for use
@@ -43,6 +46,41 @@ func PartitionSet(name string) *PartitionSetApplyConfiguration {
 	return b
 }
 
+// ExtractPartitionSet extracts the applied configuration owned by fieldManager from
+// partitionSet. If no managedFields are found in partitionSet for fieldManager, a
+// PartitionSetApplyConfiguration is returned with only the Name, Namespace (if applicable),
+// APIVersion and Kind populated. It is possible that no managed fields were found for because other
+// field managers have taken ownership of all the fields previously owned by fieldManager, or because
+// the fieldManager never owned fields any fields.
+// partitionSet must be a unmodified PartitionSet API object that was retrieved from the Kubernetes API.
+// ExtractPartitionSet provides a way to perform a extract/modify-in-place/apply workflow.
+// Note that an extracted apply configuration will contain fewer fields than what the fieldManager previously
+// applied if another fieldManager has updated or force applied any of the previously applied fields.
+// Experimental!
+func ExtractPartitionSet(partitionSet *topologyv1alpha1.PartitionSet, fieldManager string) (*PartitionSetApplyConfiguration, error) {
+	return extractPartitionSet(partitionSet, fieldManager, "")
+}
+
+// ExtractPartitionSetStatus is the same as ExtractPartitionSet except
+// that it extracts the status subresource applied configuration.
+// Experimental!
+func ExtractPartitionSetStatus(partitionSet *topologyv1alpha1.PartitionSet, fieldManager string) (*PartitionSetApplyConfiguration, error) {
+	return extractPartitionSet(partitionSet, fieldManager, "status")
+}
+
+func extractPartitionSet(partitionSet *topologyv1alpha1.PartitionSet, fieldManager string, subresource string) (*PartitionSetApplyConfiguration, error) {
+	b := &PartitionSetApplyConfiguration{}
+	err := managedfields.ExtractInto(partitionSet, internal.Parser().Type("com.github.kcp-dev.kcp.sdk.apis.topology.v1alpha1.PartitionSet"), fieldManager, b, subresource)
+	if err != nil {
+		return nil, err
+	}
+	b.WithName(partitionSet.Name)
+
+	b.WithKind("PartitionSet")
+	b.WithAPIVersion("topology.kcp.io/v1alpha1")
+	return b, nil
+}
+
 // WithKind sets the Kind field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Kind field is set to the value of the last call.
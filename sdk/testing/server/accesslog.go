@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// maxAccessLogSize is the size at which the access log is rotated.
+const maxAccessLogSize = 64 << 20
+
+// maxAccessLogBackups is the number of rotated access logs that are kept, as
+// <path>.1 (the most recent) to <path>.<maxAccessLogBackups>.
+const maxAccessLogBackups = 3
+
+// accessLogMarker identifies the lines of the apiserver's HTTP request
+// logging, which is logged at --v=3 and above, in the klog output of kcp.
+var accessLogMarker = []byte(`] "HTTP" `)
+
+// accessLogWriter copies the HTTP request log lines of the output written to it
+// to a file, rotating the file at maxSize. It never fails a write, so it can be
+// used in an io.MultiWriter with the regular log output.
+type accessLogWriter struct {
+	lock    sync.Mutex
+	path    string
+	maxSize int64
+	file    *os.File
+	size    int64
+	partial []byte
+}
+
+func newAccessLogWriter(path string, maxSize int64) (*accessLogWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("could not create access log directory: %w", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not create access log: %w", err)
+	}
+	return &accessLogWriter{path: path, maxSize: maxSize, file: f}, nil
+}
+
+func (w *accessLogWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	data := append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		if line := data[:i+1]; bytes.Contains(line, accessLogMarker) {
+			w.writeLine(line)
+		}
+		data = data[i+1:]
+	}
+	w.partial = append([]byte(nil), data...)
+
+	return len(p), nil
+}
+
+func (w *accessLogWriter) writeLine(line []byte) {
+	if w.file == nil {
+		return
+	}
+	if w.size > 0 && w.size+int64(len(line)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return
+		}
+	}
+	n, _ := w.file.Write(line)
+	w.size += int64(n)
+}
+
+func (w *accessLogWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	w.file = nil
+	for i := maxAccessLogBackups - 1; i > 0; i-- {
+		if err := os.Rename(fmt.Sprintf("%s.%d", w.path, i), fmt.Sprintf("%s.%d", w.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(w.path, w.path+".1"); err != nil {
+		return err
+	}
+	f, err := os.Create(w.path)
+	if err != nil {
+		return err
+	}
+	w.file = f
+	w.size = 0
+	return nil
+}
+
+// Close closes the access log. Later writes are dropped.
+func (w *accessLogWriter) Close() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.file != nil {
+		w.file.Close()
+		w.file = nil
+	}
+}
+
+// AccessLogFiles returns the files of the access log at path, including the
+// rotated ones, from the oldest to the most recent.
+func AccessLogFiles(path string) []string {
+	var files []string
+	for i := maxAccessLogBackups; i > 0; i-- {
+		rotated := fmt.Sprintf("%s.%d", path, i)
+		if _, err := os.Stat(rotated); err == nil {
+			files = append(files, rotated)
+		}
+	}
+	if _, err := os.Stat(path); err == nil {
+		files = append(files, path)
+	}
+	return files
+}
+
+func validateAccessLogPath(path string) error {
+	if path == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	if filepath.IsAbs(path) {
+		return fmt.Errorf("must be relative to the artifact directory")
+	}
+	if clean := filepath.Clean(path); clean != path || clean == "." || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("must be a clean path inside the artifact directory")
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const httpLogLine = `I1014 12:00:00.000000   42 httplog.go:134] "HTTP" verb="GET" URI="/readyz" latency="1.2ms" userAgent="e2e" audit-ID="abc" srcIP="127.0.0.1:4242" resp=200` + "\n"
+
+func TestAccessLogWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "access.log")
+	w, err := newAccessLogWriter(path, int64(2*len(httpLogLine)))
+	require.NoError(t, err)
+
+	// lines are split across writes and mixed with other output
+	other := "I1014 12:00:00.000000   42 controller.go:1] \"Starting\"\n"
+	output := other + httpLogLine + other + httpLogLine + httpLogLine
+	for len(output) > 0 {
+		n := min(7, len(output))
+		written, err := w.Write([]byte(output[:n]))
+		require.NoError(t, err)
+		require.Equal(t, n, written)
+		output = output[n:]
+	}
+	w.Close()
+
+	_, err = w.Write([]byte(httpLogLine))
+	require.NoError(t, err, "writes after close must be dropped silently")
+
+	files := AccessLogFiles(path)
+	require.Equal(t, []string{path + ".1", path}, files)
+	var lines int
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		require.NoError(t, err)
+		lines += strings.Count(string(data), "\n")
+		require.NotContains(t, string(data), "Starting")
+	}
+	require.Equal(t, 3, lines)
+}
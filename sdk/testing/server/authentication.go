@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	apiserverapi "k8s.io/apiserver/pkg/apis/apiserver"
+	apiserverinstall "k8s.io/apiserver/pkg/apis/apiserver/install"
+	apiservervalidation "k8s.io/apiserver/pkg/apis/apiserver/validation"
+	authenticationcel "k8s.io/apiserver/pkg/authentication/cel"
+)
+
+var authenticationConfigCodecs = func() serializer.CodecFactory {
+	scheme := runtime.NewScheme()
+	apiserverinstall.Install(scheme)
+	return serializer.NewCodecFactory(scheme, serializer.EnableStrict)
+}()
+
+// validateAuthenticationConfig checks that the file at path holds a valid
+// AuthenticationConfiguration as accepted by --authentication-config.
+func validateAuthenticationConfig(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	obj, _, err := authenticationConfigCodecs.UniversalDecoder().Decode(data, nil, &apiserverapi.AuthenticationConfiguration{})
+	if err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	config, ok := obj.(*apiserverapi.AuthenticationConfiguration)
+	if !ok {
+		return fmt.Errorf("%s holds %T, expected an AuthenticationConfiguration", path, obj)
+	}
+	if errs := apiservervalidation.ValidateAuthenticationConfiguration(authenticationcel.NewDefaultCompiler(), config, nil); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	return nil
+}
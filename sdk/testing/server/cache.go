@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+)
+
+// cacheServer is a cache server running as a separate process for the duration
+// of a test. It reuses the client side of externalKCPServer for its kubeconfig.
+type cacheServer struct {
+	*externalKCPServer
+
+	cancel           func()
+	shutdownComplete <-chan struct{}
+}
+
+// StartCacheServer starts the kcp cache server as a separate process and waits
+// for it to be ready. The server is stopped when the test ends. Its kubeconfig
+// has a "base" and a "shard-base" context and can be passed to kcp via
+// --cache-kubeconfig. The given arguments are passed to the cache server.
+func StartCacheServer(t TestingT, args ...string) RunningServer {
+	t.Helper()
+
+	artifactDir, dataDir, err := ScratchDirs(t)
+	if err != nil {
+		t.Fatalf("failed to create scratch dirs: %v", err)
+	}
+	rootDir := filepath.Join(dataDir, "cache")
+
+	ports := make([]string, 3)
+	for i := range ports {
+		if ports[i], err = GetFreePort(t); err != nil {
+			t.Fatalf("failed to get free port: %v", err)
+		}
+	}
+	securePort, etcdClientPort, etcdPeerPort := ports[0], ports[1], ports[2]
+
+	cfg := Config{
+		Name:        "cache",
+		ArtifactDir: artifactDir,
+		DataDir:     dataDir,
+		Args: append([]string{
+			"--root-directory=" + rootDir,
+			"--secure-port=" + securePort,
+			"--embedded-etcd-client-port=" + etcdClientPort,
+			"--embedded-etcd-peer-port=" + etcdPeerPort,
+		}, args...),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	shutdownComplete, err := runExecutable(ctx, t, "cache-server", "CACHE", nil, cfg)
+	if err != nil {
+		cancel()
+		t.Fatalf("failed to start cache server: %v", err)
+	}
+	s := &cacheServer{
+		cancel: func() {
+			cancel()
+			<-shutdownComplete
+		},
+		shutdownComplete: shutdownComplete,
+	}
+	t.Cleanup(s.Stop)
+
+	certPath := filepath.Join(rootDir, "apiserver.crt")
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		if _, err := os.Stat(certPath); err != nil {
+			return false, fmt.Sprintf("failed to stat %s: %v", certPath, err)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "cache server did not create its serving certificate")
+
+	cert, err := os.ReadFile(certPath)
+	if err != nil {
+		t.Fatalf("failed to read cache server certificate: %v", err)
+	}
+	kubeconfigPath := filepath.Join(rootDir, "cache.kubeconfig")
+	if err := clientcmd.WriteToFile(clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"cache": {
+				Server:                   "https://localhost:" + securePort,
+				CertificateAuthorityData: cert,
+			},
+		},
+		Contexts: map[string]*clientcmdapi.Context{
+			"base":       {Cluster: "cache"},
+			"shard-base": {Cluster: "cache"},
+		},
+		CurrentContext: "base",
+	}, kubeconfigPath); err != nil {
+		t.Fatalf("failed to write cache server kubeconfig: %v", err)
+	}
+
+	srv, err := NewExternalKCPServer(cfg.Name, kubeconfigPath, map[string]string{"cache": kubeconfigPath}, "")
+	if err != nil {
+		t.Fatalf("failed to load cache server kubeconfig: %v", err)
+	}
+	s.externalKCPServer = srv.(*externalKCPServer)
+
+	readyCtx, readyCancel := context.WithTimeout(ctx, defaultReadinessTimeout)
+	defer readyCancel()
+	if err := waitForReady(readyCtx, s.BaseConfig(t), defaultReadinessTimeout); err != nil {
+		t.Fatalf("cache server did not become ready: %v", err)
+	}
+
+	return s
+}
+
+// Stop stops the cache server and waits for the process to exit.
+func (s *cacheServer) Stop() {
+	s.cancel()
+}
+
+// Stopped returns whether the cache server process has exited.
+func (s *cacheServer) Stopped() bool {
+	select {
+	case <-s.shutdownComplete:
+		return true
+	default:
+		return false
+	}
+}
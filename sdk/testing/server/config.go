@@ -16,7 +16,26 @@ limitations under the License.
 
 package server
 
-import "path/filepath"
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/validation/path"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	cliflag "k8s.io/component-base/cli/flag"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+)
 
 // Config qualify a kcp server to start
 //
@@ -28,8 +47,78 @@ type Config struct {
 	DataDir     string
 	ClientCADir string
 
+	// ArtifactSubdir, if set, separates the artifacts of this server from
+	// those of other servers with the same name in the same test.
+	ArtifactSubdir string
+
+	// DataDirOnTmpfs places the data directory on tmpfs, if available.
+	DataDirOnTmpfs bool
+
+	// LogicalClusterAdminKubeconfig makes kcp connect to shards with a
+	// generated logical-cluster-admin kubeconfig.
+	LogicalClusterAdminKubeconfig bool
+
+	// EtcdTracing exports apiserver and etcd client traces to the traces.jsonl
+	// artifact.
+	EtcdTracing bool
+
+	// AccessLogPath, if set, is where the HTTP request log of kcp is written
+	// to, relative to the artifact directory.
+	AccessLogPath string
+
+	// OpenMetricsArtifacts writes the metrics gathered at the end of the test
+	// in the OpenMetrics text format instead of the Prometheus text format.
+	OpenMetricsArtifacts bool
+
+	// EtcdSizeSamplingInterval, if positive, is how often the etcd database
+	// size is sampled to the etcd-size.csv artifact.
+	EtcdSizeSamplingInterval time.Duration
+
+	// StartupLeakCheck fails the test if the fixture leaves goroutines behind
+	// after cleanup.
+	StartupLeakCheck bool
+
 	LogToConsole bool
 	RunInProcess bool
+
+	// LogWriter, if set, additionally receives the log output of the server.
+	// In-process servers log to it through a contextual logger instead of
+	// the global klog output. It must be safe for concurrent use.
+	LogWriter io.Writer
+
+	// GOMAXPROCS, if positive, limits the number of OS threads executing Go
+	// code of the server at the same time.
+	GOMAXPROCS int
+
+	// Env holds environment variables set for the server process in addition
+	// to the environment of the test process.
+	Env map[string]string
+
+	// ExternalVirtualWorkspaces runs the virtual workspaces in a separate
+	// virtual-workspaces process instead of in kcp.
+	ExternalVirtualWorkspaces bool
+
+	// ExternalURLScheme and ExternalURLPathPrefix, if set, are used for the
+	// URLs kcp advertises to clients, as if it was behind a proxy.
+	ExternalURLScheme     string
+	ExternalURLPathPrefix string
+
+	// Shards, if greater than one, starts that many kcp shards, a cache server
+	// and a front-proxy with the sharded-test-server instead of a single kcp.
+	Shards int
+
+	// FeatureGates are merged into the --feature-gates defaults of the
+	// server, overriding them on conflict.
+	FeatureGates map[string]bool
+
+	// ReadinessTimeout, if positive, is how long the fixture waits for the
+	// admin kubeconfig and then for readiness of the server, each. It
+	// defaults to two minutes.
+	ReadinessTimeout time.Duration
+
+	// ReadinessHooks are run in order after the server reports ready. All
+	// of them must succeed before the server is considered ready.
+	ReadinessHooks []func(ctx context.Context, cfg *rest.Config) error
 }
 
 // Option a function that wish to modify a given kcp configuration.
@@ -54,8 +143,144 @@ func WithScratchDirectories(artifactDir, dataDir string) Option {
 	}
 }
 
-// WithCustomArguments applies provided arguments to a given kcp configuration.
+// WithArtifactSubdir makes a given kcp configuration write its logs and
+// artifacts to the named subdirectory of its artifact directory. Use it to keep
+// several servers started by the same test, e.g. multiple PrivateKcpServer
+// calls, from overwriting each other's artifacts.
+func WithArtifactSubdir(name string) Option {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		panic(fmt.Sprintf("invalid artifact subdirectory %q, must be a single path element", name))
+	}
+	return func(cfg *Config) {
+		cfg.ArtifactSubdir = name
+	}
+}
+
+// WithRootDirectoryOnTmpfs places the data directory, i.e. the --root-directory
+// including the embedded etcd, of a given kcp configuration on tmpfs for fast
+// I/O. This is only supported on Linux with /dev/shm mounted, elsewhere the
+// data directory is left unchanged. The data is lost when the host reboots and
+// counts against memory, so keep it for servers with small data sets.
+func WithRootDirectoryOnTmpfs() Option {
+	return func(cfg *Config) {
+		cfg.DataDirOnTmpfs = true
+	}
+}
+
+// WithEtcdTracing enables tracing of every request for a given kcp
+// configuration via --tracing-config-file with a sampling rate of 100%. This
+// includes the spans of the etcd client, e.g. etcdserverpb.KV/Range, so slow
+// storage shows up in the traces. The fixture runs an OTLP collector that writes
+// each span as a JSON line to traces.jsonl in the artifact directory of the
+// server. It requires the APIServerTracing feature gate, which is on by default.
+func WithEtcdTracing() Option {
+	return func(cfg *Config) {
+		cfg.EtcdTracing = true
+	}
+}
+
+// WithAccessLog makes the fixture write the HTTP request log of a given kcp
+// configuration, i.e. one line per request with verb, URI, latency and
+// response code, to path relative to the artifact directory. The lines are
+// taken from the --v=3 output of kcp, so this is only supported for servers not
+// running in process. The file is rotated at 64MiB, keeping up to 3 rotated
+// files as <path>.1 to <path>.3.
+func WithAccessLog(path string) Option {
+	if err := validateAccessLogPath(path); err != nil {
+		panic(fmt.Sprintf("invalid access log path %q: %v", path, err))
+	}
+	return func(cfg *Config) {
+		cfg.AccessLogPath = path
+	}
+}
+
+// WithStartupLeakCheck makes the fixture snapshot the running goroutines before
+// it starts the servers and fail the test if, after all cleanups ran, goroutines
+// started since are still running. This includes goroutines leaked by a failed
+// startup and by readiness hooks. Idle HTTP connections and klog's flush daemon
+// are ignored. Goroutines of other tests running in parallel are reported as
+// well, so only use it in tests that do not run in parallel, and only with
+// servers that do not run in process.
+func WithStartupLeakCheck() Option {
+	return func(cfg *Config) {
+		cfg.StartupLeakCheck = true
+	}
+}
+
+// WithOpenMetricsArtifacts makes the fixture write the metrics it gathers from
+// a given kcp configuration at the end of the test in the OpenMetrics 1.0.0 text
+// format to <name>-metrics.openmetrics.txt, which promtool and other OpenMetrics
+// parsers can ingest directly. Counters always carry the _total suffix, and
+// exemplars are kept if the server exposes any.
+func WithOpenMetricsArtifacts() Option {
+	return func(cfg *Config) {
+		cfg.OpenMetricsArtifacts = true
+	}
+}
+
+// WithGOMAXPROCS limits a given kcp configuration to n OS threads executing Go
+// code at the same time by setting GOMAXPROCS for the kcp process. With
+// WithRunInProcess the limit applies to the whole test process while the server
+// is running.
+func WithGOMAXPROCS(n int) Option {
+	if n < 1 {
+		panic(fmt.Sprintf("invalid GOMAXPROCS %d, must be positive", n))
+	}
+	return func(cfg *Config) {
+		cfg.GOMAXPROCS = n
+	}
+}
+
+// WithReadinessTimeout sets how long the fixture waits for a given kcp
+// configuration to write its admin kubeconfig and then to become ready, each,
+// instead of the default of two minutes. Raise it for slow environments, e.g.
+// with the race detector, or when debugging the server during startup. It
+// also bounds the wait for the server started by WithExternalVirtualWorkspaces.
+func WithReadinessTimeout(d time.Duration) Option {
+	if d <= 0 {
+		panic(fmt.Sprintf("invalid readiness timeout %s, must be positive", d))
+	}
+	return func(cfg *Config) {
+		cfg.ReadinessTimeout = d
+	}
+}
+
+// WithDeterministicProfiling prepares a given kcp configuration for taking CPU
+// profiles that are comparable between runs: Go code runs on a single OS thread
+// and only the admin battery is included, so no default workspace types beyond
+// universal are created in the background. This is meant for profiling only,
+// the server is much slower than usual and its latency and throughput say
+// nothing about the performance of kcp.
+func WithDeterministicProfiling() Option {
+	return func(cfg *Config) {
+		WithGOMAXPROCS(1)(cfg)
+		cfg.Args = append(cfg.Args, "--batteries-included=admin")
+	}
+}
+
+// WithLogicalClusterAdminKubeconfig makes kcp of a given kcp configuration use
+// a generated kubeconfig of a system:kcp:logical-cluster-admin for connections
+// to shards, like in a sharded deployment, instead of the loopback client. The
+// client certificate is signed by a generated client CA passed as
+// --client-ca-file, so it must not be combined with another --client-ca-file.
+// Use LogicalClusterAdminKubeconfig to read the kubeconfig.
+func WithLogicalClusterAdminKubeconfig() Option {
+	return func(cfg *Config) {
+		cfg.LogicalClusterAdminKubeconfig = true
+	}
+}
+
+// WithCustomArguments appends the provided arguments to the arguments of a
+// given kcp configuration, after those added by earlier options.
 func WithCustomArguments(args ...string) Option {
+	return func(cfg *Config) {
+		cfg.Args = append(cfg.Args, args...)
+	}
+}
+
+// WithReplacedArguments replaces the arguments of a given kcp configuration
+// with the provided ones, including those added by earlier options.
+func WithReplacedArguments(args ...string) Option {
 	return func(cfg *Config) {
 		cfg.Args = args
 	}
@@ -78,9 +303,496 @@ func WithRunInProcess() Option {
 	}
 }
 
-// WithLogToConsole sets the kcp server to log to console.
+// WithExternalVirtualWorkspaces runs the virtual workspaces of a given kcp
+// configuration in a separate virtual-workspaces process, like in a split
+// deployment. Both processes must be ready before the server is considered
+// ready. Use VirtualWorkspacesConfig to talk to the virtual-workspaces server.
+func WithExternalVirtualWorkspaces() Option {
+	return func(cfg *Config) {
+		cfg.ExternalVirtualWorkspaces = true
+	}
+}
+
+// WithExternalURLScheme sets the scheme of the URLs a given kcp configuration
+// advertises to clients, e.g. workspace and APIExport virtual workspace URLs,
+// like for a shard behind a TLS terminating proxy. The server itself keeps
+// serving https. scheme must be "http" or "https".
+func WithExternalURLScheme(scheme string) Option {
+	if scheme != "http" && scheme != "https" {
+		panic(fmt.Sprintf("invalid external URL scheme %q, must be http or https", scheme))
+	}
+	return func(cfg *Config) {
+		cfg.ExternalURLScheme = scheme
+	}
+}
+
+// WithExternalURLPathPrefix sets a path prefix for the URLs a given kcp
+// configuration advertises to clients, like for a shard exposed below a path
+// of an ingress. The server itself keeps serving at the root path. prefix must
+// start with a slash and must not end with one.
+func WithExternalURLPathPrefix(prefix string) Option {
+	if !strings.HasPrefix(prefix, "/") || strings.HasSuffix(prefix, "/") {
+		panic(fmt.Sprintf("invalid external URL path prefix %q, must start and not end with a slash", prefix))
+	}
+	return func(cfg *Config) {
+		cfg.ExternalURLPathPrefix = prefix
+	}
+}
+
+// WithShards starts n kcp shards behind a front-proxy for a given kcp
+// configuration, using the sharded-test-server. The shards are called "root",
+// "shard-1", "shard-2" and so on, see RunningServer.ShardNames. The arguments
+// and feature gates of the configuration are passed to all shards. Options
+// that only apply to a single kcp process, e.g. WithRunInProcess,
+// WithEtcdTracing or WithAccessLog, make the fixture fail.
+func WithShards(n int) Option {
+	if n < 1 {
+		panic(fmt.Sprintf("invalid number of shards %d, must be at least 1", n))
+	}
+	return func(cfg *Config) {
+		cfg.Shards = n
+	}
+}
+
+// WithFeatureGates sets the given feature gates for a given kcp configuration.
+// They are merged with the default --feature-gates of the fixture into a single
+// flag, and win on conflict. Later calls override gates of earlier ones.
+func WithFeatureGates(gates map[string]bool) Option {
+	for name := range gates {
+		if name == "" {
+			panic("invalid feature gate, name must not be empty")
+		}
+	}
+	return func(cfg *Config) {
+		if cfg.FeatureGates == nil {
+			cfg.FeatureGates = map[string]bool{}
+		}
+		for name, enabled := range gates {
+			cfg.FeatureGates[name] = enabled
+		}
+	}
+}
+
+// WithEnv sets the given environment variables for the kcp process of a given
+// kcp configuration, on top of the environment of the test process. Later calls
+// override variables of earlier ones. It has no effect with WithRunInProcess.
+func WithEnv(env map[string]string) Option {
+	for name := range env {
+		if name == "" || strings.Contains(name, "=") {
+			panic(fmt.Sprintf("invalid environment variable name %q", name))
+		}
+	}
+	return func(cfg *Config) {
+		if cfg.Env == nil {
+			cfg.Env = map[string]string{}
+		}
+		for name, value := range env {
+			cfg.Env[name] = value
+		}
+	}
+}
+
+// WithLogToConsole sets the kcp server to log to console. Every line is
+// prefixed with the test and the server name, e.g. "TestFoo/main: ".
 func WithLogToConsole() Option {
 	return func(cfg *Config) {
 		cfg.LogToConsole = true
 	}
 }
+
+// WithLogWriter additionally writes the log output of the kcp server to w,
+// e.g. to assert on log lines in a test. In-process servers use a contextual
+// logger writing to w, so that concurrently running in-process servers do not
+// share the global klog output. Log lines emitted through the global klog
+// functions instead of the context logger are not captured. w must be safe for
+// concurrent use.
+func WithLogWriter(w io.Writer) Option {
+	if w == nil {
+		panic("invalid log writer, must not be nil")
+	}
+	return func(cfg *Config) {
+		cfg.LogWriter = w
+	}
+}
+
+// WithReadinessHook adds a custom readiness condition for a given kcp
+// configuration. The hook is called with the root shard system:masters config
+// after /livez and /readyz succeeded, and must return nil for the server to be
+// considered ready. Multiple hooks run in the order they were added.
+func WithReadinessHook(fn func(ctx context.Context, cfg *rest.Config) error) Option {
+	return func(cfg *Config) {
+		cfg.ReadinessHooks = append(cfg.ReadinessHooks, fn)
+	}
+}
+
+// WithRootWorkspaceType sets the default type of workspaces created in root
+// without an explicit type for a given kcp configuration, by pointing
+// spec.defaultChildWorkspaceType of the root WorkspaceType to the WorkspaceType
+// root:<typeName> once the server is ready. The WorkspaceType does not have to
+// exist yet, it is resolved when a workspace is created.
+func WithRootWorkspaceType(typeName string) Option {
+	if typeName == "" {
+		panic("invalid root workspace type, must not be empty")
+	}
+	if strings.Contains(typeName, ":") {
+		panic(fmt.Sprintf("invalid root workspace type %q, must be the name of a WorkspaceType in root", typeName))
+	}
+	patch := fmt.Sprintf(`{"spec":{"defaultChildWorkspaceType":{"name":%q,"path":%q}}}`, typeName, core.RootCluster.String())
+
+	return WithReadinessHook(func(ctx context.Context, cfg *rest.Config) error {
+		client, err := kcpclientset.NewForConfig(cfg)
+		if err != nil {
+			return err
+		}
+		_, err = client.Cluster(core.RootCluster.Path()).TenancyV1alpha1().WorkspaceTypes().Patch(ctx, "root", types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+		return err
+	})
+}
+
+// WithBootstrapRBAC binds the cluster role clusterRole in the root workspace to
+// the user subject for a given kcp configuration once the server is ready, e.g.
+// WithBootstrapRBAC("e2e-admin", "cluster-admin") to act as an admin of root
+// with a ClientCAUserConfig for that user. The server is only considered ready
+// once the authorizer grants the permissions, so the user can use them right
+// away.
+func WithBootstrapRBAC(subject, clusterRole string) Option {
+	if subject == "" {
+		panic("invalid bootstrap RBAC subject, must not be empty")
+	}
+	if clusterRole == "" {
+		panic("invalid bootstrap RBAC cluster role, must not be empty")
+	}
+	for _, name := range []string{subject, clusterRole} {
+		if msgs := path.IsValidPathSegmentName(name); len(msgs) > 0 {
+			panic(fmt.Sprintf("invalid bootstrap RBAC name %q: %s", name, strings.Join(msgs, ", ")))
+		}
+	}
+
+	return WithReadinessHook(func(ctx context.Context, cfg *rest.Config) error {
+		return bootstrapRBAC(ctx, cfg, subject, clusterRole)
+	})
+}
+
+// replicationControllers are the controllers labelling objects for replication
+// to the cache server. The replication controller itself always runs.
+var replicationControllers = []string{
+	"apisreplicateclusterrole",
+	"apisreplicateclusterrolebinding",
+	"apisreplicatelogicalcluster",
+	"corereplicateclusterrole",
+	"corereplicateclusterrolebinding",
+	"tenancyreplicateclusterrole",
+	"tenancyreplicationclusterrolebinding",
+	"tenancyreplicatelogicalcluster",
+}
+
+// WithReplicationControllers enables the controllers selecting RBAC and
+// LogicalClusters for replication to the cache server for a given kcp
+// configuration. They already run with --run-controllers, which is the
+// default, so this is only needed together with --run-controllers=false.
+func WithReplicationControllers() Option {
+	return func(cfg *Config) {
+		cfg.Args = append(cfg.Args, "--unsupported-run-individual-controllers="+strings.Join(replicationControllers, ","))
+	}
+}
+
+// quotaControllers are the controllers enforcing ResourceQuotas in workspaces
+// and driving workspaces through initialization, which is how quota templates
+// are applied to new workspaces of a type. The apibinder is the initializer of
+// the default workspace types.
+var quotaControllers = []string{
+	"apibinder",
+	"quota",
+	"workspace-scheduler",
+}
+
+// WithQuotaControllers enables the controllers enforcing ResourceQuotas in
+// workspaces and initializing new workspaces for a given kcp configuration.
+// They already run with --run-controllers, which is the default, so this is
+// only needed together with --run-controllers=false.
+func WithQuotaControllers() Option {
+	return func(cfg *Config) {
+		cfg.Args = append(cfg.Args, "--unsupported-run-individual-controllers="+strings.Join(quotaControllers, ","))
+	}
+}
+
+// watchCacheResourceRegexp matches the resource[.group] keys accepted by
+// --watch-cache-sizes.
+var watchCacheResourceRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+// WithWatchCacheSizes sets --watch-cache-sizes for a given kcp configuration.
+// Keys are lowercase resource names with an optional group suffix, e.g.
+// "configmaps" or "workspaces.tenancy.kcp.io". A size of 0 disables the watch
+// cache for that resource.
+func WithWatchCacheSizes(sizes map[string]int) Option {
+	entries := make([]string, 0, len(sizes))
+	for resource, size := range sizes {
+		if !watchCacheResourceRegexp.MatchString(resource) {
+			panic(fmt.Sprintf("invalid watch cache resource %q, expected resource[.group]", resource))
+		}
+		if size < 0 {
+			panic(fmt.Sprintf("invalid watch cache size %d for resource %q, must not be negative", size, resource))
+		}
+		entries = append(entries, fmt.Sprintf("%s#%d", resource, size))
+	}
+	sort.Strings(entries)
+
+	return func(cfg *Config) {
+		if len(entries) == 0 {
+			return
+		}
+		cfg.Args = append(cfg.Args, "--watch-cache-sizes="+strings.Join(entries, ","))
+	}
+}
+
+// WithHomeWorkspaces sets --enable-home-workspaces for a given kcp
+// configuration. Home workspaces are enabled by default; when enabled, a
+// user's home workspace is provisioned on first access of "~" in root.
+func WithHomeWorkspaces(enabled bool) Option {
+	return func(cfg *Config) {
+		cfg.Args = append(cfg.Args, "--enable-home-workspaces="+strconv.FormatBool(enabled))
+	}
+}
+
+// WithEtcdCompactionInterval sets --etcd-compaction-interval for a given kcp
+// configuration. Revisions older than roughly one interval are compacted, and
+// watches starting from them fail with 410 Gone. A zero interval disables
+// compaction by the server.
+func WithEtcdCompactionInterval(d time.Duration) Option {
+	if d < 0 {
+		panic(fmt.Sprintf("invalid etcd compaction interval %s, must not be negative", d))
+	}
+	return func(cfg *Config) {
+		cfg.Args = append(cfg.Args, "--etcd-compaction-interval="+d.String())
+	}
+}
+
+// WithDiscoveryCacheMaxAge sets --discovery-cache-max-age for a given kcp
+// configuration, making discovery and OpenAPI responses carry a Cache-Control
+// max-age of d and an ETag, as needed by caching proxies in front of kcp. d is
+// rounded down to seconds and must be at least one second.
+func WithDiscoveryCacheMaxAge(d time.Duration) Option {
+	if d < time.Second {
+		panic(fmt.Sprintf("invalid discovery cache max-age %s, must be at least 1s", d))
+	}
+	return func(cfg *Config) {
+		cfg.Args = append(cfg.Args, "--discovery-cache-max-age="+d.String())
+	}
+}
+
+// WithServerTiming sets --server-timing for a given kcp configuration, making
+// every response carry a Server-Timing header with the time spent in the
+// phases of the request, e.g. storage;dur=1.234 in milliseconds.
+func WithServerTiming() Option {
+	return func(cfg *Config) {
+		cfg.Args = append(cfg.Args, "--server-timing")
+	}
+}
+
+// WithEtcdCountMetricPollPeriod sets --etcd-count-metric-poll-period for a
+// given kcp configuration, i.e. how often the number of objects per resource is
+// counted in etcd. The counts are exposed as apiserver_storage_objects and feed
+// the work estimates of API priority and fairness. The default is one minute,
+// use a short period to observe counts after bulk operations. A zero period
+// disables counting.
+func WithEtcdCountMetricPollPeriod(d time.Duration) Option {
+	if d < 0 {
+		panic(fmt.Sprintf("invalid etcd count metric poll period %s, must not be negative", d))
+	}
+	return func(cfg *Config) {
+		cfg.Args = append(cfg.Args, "--etcd-count-metric-poll-period="+d.String())
+	}
+}
+
+// WithEtcdSizeSampling samples the size of the etcd database of a given kcp
+// configuration every interval while the test runs, as reported by
+// apiserver_storage_size_bytes. The samples are written to etcd-size.csv in the
+// artifact directory of the server and returned by
+// EtcdSizeSamples. The size is the physically allocated size of
+// the database file, which only shrinks on defragmentation, so space freed by
+// compaction shows as a plateau rather than a decrease.
+func WithEtcdSizeSampling(interval time.Duration) Option {
+	if interval <= 0 {
+		panic(fmt.Sprintf("invalid etcd size sampling interval %s, must be positive", interval))
+	}
+	return func(cfg *Config) {
+		cfg.EtcdSizeSamplingInterval = interval
+	}
+}
+
+// WithEtcdAutoCompaction enables auto-compaction of the embedded etcd for a
+// given kcp configuration. mode is "periodic", with retention being a duration
+// like "10m" or a number of hours, or "revision", with retention being the
+// number of revisions to keep. Revision mode compacts every five minutes. This
+// is independent of the compaction requests of the apiserver configured with
+// WithEtcdCompactionInterval.
+func WithEtcdAutoCompaction(mode, retention string) Option {
+	switch mode {
+	case "periodic":
+		if _, err := time.ParseDuration(retention); err != nil {
+			if hours, err := strconv.Atoi(retention); err != nil || hours < 0 {
+				panic(fmt.Sprintf("invalid etcd auto-compaction retention %q, must be a duration or a number of hours in periodic mode", retention))
+			}
+		}
+	case "revision":
+		if revs, err := strconv.ParseInt(retention, 10, 64); err != nil || revs < 0 {
+			panic(fmt.Sprintf("invalid etcd auto-compaction retention %q, must be a number of revisions in revision mode", retention))
+		}
+	default:
+		panic(fmt.Sprintf("invalid etcd auto-compaction mode %q, must be periodic or revision", mode))
+	}
+	return func(cfg *Config) {
+		cfg.Args = append(cfg.Args,
+			"--embedded-etcd-auto-compaction-mode="+mode,
+			"--embedded-etcd-auto-compaction-retention="+retention,
+		)
+	}
+}
+
+// WithEtcdPrefix sets --etcd-prefix for a given kcp configuration, the prefix
+// of all keys kcp stores in etcd. Servers sharing one etcd via --etcd-servers
+// must use distinct prefixes to not see each other's data. The prefix must be
+// an absolute path like "/kcp-a" without empty, "." or ".." segments.
+func WithEtcdPrefix(prefix string) Option {
+	if !strings.HasPrefix(prefix, "/") || prefix == "/" {
+		panic(fmt.Sprintf("invalid etcd prefix %q, must be an absolute path", prefix))
+	}
+	for _, segment := range strings.Split(prefix[1:], "/") {
+		if segment == "" || segment == "." || segment == ".." || strings.TrimSpace(segment) != segment {
+			panic(fmt.Sprintf("invalid etcd prefix %q, must not contain empty, dot or padded segments", prefix))
+		}
+	}
+	return func(cfg *Config) {
+		cfg.Args = append(cfg.Args, "--etcd-prefix="+prefix)
+	}
+}
+
+// WithGoawayChance sets --goaway-chance for a given kcp configuration, the
+// fraction of HTTP/2 requests after which the server sends a GOAWAY to make the
+// client reconnect. The apiserver accepts at most 0.02, i.e. one in 50
+// requests.
+func WithGoawayChance(chance float64) Option {
+	if chance < 0 || chance > 0.02 {
+		panic(fmt.Sprintf("invalid goaway chance %v, must be between 0 and 0.02", chance))
+	}
+	return func(cfg *Config) {
+		cfg.Args = append(cfg.Args, "--goaway-chance="+strconv.FormatFloat(chance, 'f', -1, 64))
+	}
+}
+
+// WithShutdownDelay sets --shutdown-delay-duration and
+// --shutdown-send-retry-after for a given kcp configuration. During the delay
+// after shutdown is initiated the server keeps serving while /readyz fails. With
+// sendRetryAfter, requests arriving after the delay and before in-flight
+// requests are drained are rejected with 429 and a Retry-After header instead
+// of the listener being closed.
+func WithShutdownDelay(d time.Duration, sendRetryAfter bool) Option {
+	if d < 0 {
+		panic(fmt.Sprintf("invalid shutdown delay %s, must not be negative", d))
+	}
+	return func(cfg *Config) {
+		cfg.Args = append(cfg.Args,
+			"--shutdown-delay-duration="+d.String(),
+			"--shutdown-send-retry-after="+strconv.FormatBool(sendRetryAfter),
+		)
+	}
+}
+
+// WithEventTTL sets --event-ttl for a given kcp configuration. Events are
+// deleted by etcd roughly d after they were last written, plus up to
+// --lease-reuse-duration-seconds, but at most 5% of d. Short TTLs like 10s make
+// expiry testable without waiting for the default of one hour. etcd leases
+// have a granularity of seconds.
+func WithEventTTL(d time.Duration) Option {
+	if d < time.Second {
+		panic(fmt.Sprintf("invalid event TTL %s, must be at least one second", d))
+	}
+	return func(cfg *Config) {
+		cfg.Args = append(cfg.Args, "--event-ttl="+d.String())
+	}
+}
+
+// WithTLSConfig sets --tls-min-version and --tls-cipher-suites for a given kcp
+// configuration. minVersion is a Go TLS version name, e.g. VersionTLS13, and
+// cipherSuites are IANA cipher suite names, e.g.
+// TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. Empty values leave the server
+// defaults in place. Cipher suites only apply to TLS 1.2 and below.
+func WithTLSConfig(minVersion string, cipherSuites []string) Option {
+	if minVersion != "" {
+		if _, err := cliflag.TLSVersion(minVersion); err != nil {
+			panic(fmt.Sprintf("invalid TLS min version: %v", err))
+		}
+	}
+	if _, err := cliflag.TLSCipherSuites(cipherSuites); err != nil {
+		panic(fmt.Sprintf("invalid TLS cipher suites: %v", err))
+	}
+	return func(cfg *Config) {
+		if minVersion != "" {
+			cfg.Args = append(cfg.Args, "--tls-min-version="+minVersion)
+		}
+		if len(cipherSuites) > 0 {
+			cfg.Args = append(cfg.Args, "--tls-cipher-suites="+strings.Join(cipherSuites, ","))
+		}
+	}
+}
+
+// WithCORSAllowedOrigins sets --cors-allowed-origins for a given kcp
+// configuration. Origins are regular expressions matched against the Origin
+// header of a request, e.g. `//localhost(:|$)`. A matching origin is echoed in
+// the Access-Control-Allow-Origin response header.
+func WithCORSAllowedOrigins(origins []string) Option {
+	for _, origin := range origins {
+		if origin == "" {
+			panic("invalid CORS allowed origin, must not be empty")
+		}
+		if strings.Contains(origin, ",") {
+			panic(fmt.Sprintf("invalid CORS allowed origin %q, must not contain commas", origin))
+		}
+		if _, err := regexp.Compile(origin); err != nil {
+			panic(fmt.Sprintf("invalid CORS allowed origin %q: %v", origin, err))
+		}
+	}
+	return func(cfg *Config) {
+		if len(origins) == 0 {
+			return
+		}
+		cfg.Args = append(cfg.Args, "--cors-allowed-origins="+strings.Join(origins, ","))
+	}
+}
+
+// WithAuthenticationConfig sets --authentication-config for a given kcp
+// configuration, the structured configuration of JWT authenticators, and
+// enables the StructuredAuthenticationConfiguration feature gate it requires.
+// The file must hold a valid AuthenticationConfiguration and is re-read by kcp
+// when it changes.
+func WithAuthenticationConfig(path string) Option {
+	if err := validateAuthenticationConfig(path); err != nil {
+		panic(fmt.Sprintf("invalid authentication config: %v", err))
+	}
+	return func(cfg *Config) {
+		cfg.Args = append(cfg.Args, "--authentication-config="+path)
+		WithFeatureGates(map[string]bool{"StructuredAuthenticationConfiguration": true})(cfg)
+	}
+}
+
+// WithRequestHeaderAllowedNames sets --requestheader-allowed-names for a given
+// kcp configuration. Only clients presenting a certificate signed by the
+// --requestheader-client-ca-file CA with one of the given common names may
+// assert a user via the request headers, as the front-proxy does. An empty
+// list allows any certificate signed by the CA.
+func WithRequestHeaderAllowedNames(names []string) Option {
+	for _, name := range names {
+		if name == "" {
+			panic("invalid request header allowed name, must not be empty")
+		}
+		if strings.Contains(name, ",") {
+			panic(fmt.Sprintf("invalid request header allowed name %q, must not contain commas", name))
+		}
+	}
+	return func(cfg *Config) {
+		if len(names) == 0 {
+			return
+		}
+		cfg.Args = append(cfg.Args, "--requestheader-allowed-names="+strings.Join(names, ","))
+	}
+}
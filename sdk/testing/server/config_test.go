@@ -0,0 +1,370 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithWatchCacheSizes(t *testing.T) {
+	cfg := &Config{}
+	WithWatchCacheSizes(map[string]int{
+		"secrets":                   0,
+		"configmaps":                100,
+		"workspaces.tenancy.kcp.io": 50,
+	})(cfg)
+	require.Equal(t, []string{"--watch-cache-sizes=configmaps#100,secrets#0,workspaces.tenancy.kcp.io#50"}, cfg.Args)
+
+	cfg = &Config{}
+	WithWatchCacheSizes(nil)(cfg)
+	require.Empty(t, cfg.Args)
+
+	require.Panics(t, func() { WithWatchCacheSizes(map[string]int{"ConfigMaps": 1}) })
+	require.Panics(t, func() { WithWatchCacheSizes(map[string]int{"configmaps#1": 1}) })
+	require.Panics(t, func() { WithWatchCacheSizes(map[string]int{"configmaps": -1}) })
+}
+
+func TestWithHomeWorkspaces(t *testing.T) {
+	cfg := &Config{}
+	WithHomeWorkspaces(false)(cfg)
+	require.Equal(t, []string{"--enable-home-workspaces=false"}, cfg.Args)
+}
+
+func TestWithEtcdCompactionInterval(t *testing.T) {
+	cfg := &Config{}
+	WithEtcdCompactionInterval(90 * time.Second)(cfg)
+	require.Equal(t, []string{"--etcd-compaction-interval=1m30s"}, cfg.Args)
+
+	require.Panics(t, func() { WithEtcdCompactionInterval(-time.Second) })
+}
+
+func TestWithDeterministicProfiling(t *testing.T) {
+	cfg := &Config{}
+	WithDeterministicProfiling()(cfg)
+	require.Equal(t, 1, cfg.GOMAXPROCS)
+	require.Equal(t, []string{"--batteries-included=admin"}, cfg.Args)
+
+	require.Panics(t, func() { WithGOMAXPROCS(0) })
+}
+
+func TestWithDiscoveryCacheMaxAge(t *testing.T) {
+	cfg := &Config{}
+	WithDiscoveryCacheMaxAge(time.Minute)(cfg)
+	require.Equal(t, []string{"--discovery-cache-max-age=1m0s"}, cfg.Args)
+
+	require.Panics(t, func() { WithDiscoveryCacheMaxAge(0) })
+	require.Panics(t, func() { WithDiscoveryCacheMaxAge(500 * time.Millisecond) })
+}
+
+func TestWithServerTiming(t *testing.T) {
+	cfg := &Config{}
+	WithServerTiming()(cfg)
+	require.Equal(t, []string{"--server-timing"}, cfg.Args)
+}
+
+func TestWithEtcdSizeSampling(t *testing.T) {
+	cfg := &Config{}
+	WithEtcdSizeSampling(time.Second)(cfg)
+	require.Equal(t, time.Second, cfg.EtcdSizeSamplingInterval)
+	require.Empty(t, cfg.Args)
+
+	require.Panics(t, func() { WithEtcdSizeSampling(0) })
+}
+
+func TestWithEtcdCountMetricPollPeriod(t *testing.T) {
+	cfg := &Config{}
+	WithEtcdCountMetricPollPeriod(time.Second)(cfg)
+	require.Equal(t, []string{"--etcd-count-metric-poll-period=1s"}, cfg.Args)
+
+	cfg = &Config{}
+	WithEtcdCountMetricPollPeriod(0)(cfg)
+	require.Equal(t, []string{"--etcd-count-metric-poll-period=0s"}, cfg.Args)
+
+	require.Panics(t, func() { WithEtcdCountMetricPollPeriod(-time.Second) })
+}
+
+func TestWithShutdownDelay(t *testing.T) {
+	cfg := &Config{}
+	WithShutdownDelay(5*time.Second, true)(cfg)
+	require.Equal(t, []string{"--shutdown-delay-duration=5s", "--shutdown-send-retry-after=true"}, cfg.Args)
+
+	require.Panics(t, func() { WithShutdownDelay(-time.Second, false) })
+}
+
+func TestWithEventTTL(t *testing.T) {
+	cfg := &Config{}
+	WithEventTTL(10 * time.Minute)(cfg)
+	require.Equal(t, []string{"--event-ttl=10m0s"}, cfg.Args)
+
+	require.Panics(t, func() { WithEventTTL(0) })
+	require.Panics(t, func() { WithEventTTL(500 * time.Millisecond) })
+}
+
+func TestWithTLSConfig(t *testing.T) {
+	cfg := &Config{}
+	WithTLSConfig("VersionTLS12", []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"})(cfg)
+	require.Equal(t, []string{
+		"--tls-min-version=VersionTLS12",
+		"--tls-cipher-suites=TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	}, cfg.Args)
+
+	cfg = &Config{}
+	WithTLSConfig("", nil)(cfg)
+	require.Empty(t, cfg.Args)
+
+	require.Panics(t, func() { WithTLSConfig("VersionTLS14", nil) })
+	require.Panics(t, func() { WithTLSConfig("", []string{"TLS_NOT_A_CIPHER"}) })
+}
+
+func TestWithAccessLog(t *testing.T) {
+	cfg := &Config{}
+	WithAccessLog("access.log")(cfg)
+	require.Equal(t, "access.log", cfg.AccessLogPath)
+
+	for _, path := range []string{"", "/tmp/access.log", "../access.log", "logs/../../access.log", "./access.log"} {
+		require.Panics(t, func() { WithAccessLog(path) }, path)
+	}
+}
+
+func TestWithBootstrapRBAC(t *testing.T) {
+	cfg := &Config{}
+	WithBootstrapRBAC("e2e-admin", "system:kcp:workspace:admin")(cfg)
+	require.Len(t, cfg.ReadinessHooks, 1)
+
+	require.Panics(t, func() { WithBootstrapRBAC("", "cluster-admin") })
+	require.Panics(t, func() { WithBootstrapRBAC("e2e-admin", "") })
+	require.Panics(t, func() { WithBootstrapRBAC("e2e-admin", "cluster/admin") })
+	require.Panics(t, func() { WithBootstrapRBAC("e2e-admin", "..") })
+}
+
+func TestWithEtcdAutoCompaction(t *testing.T) {
+	cfg := &Config{}
+	WithEtcdAutoCompaction("revision", "100")(cfg)
+	require.Equal(t, []string{"--embedded-etcd-auto-compaction-mode=revision", "--embedded-etcd-auto-compaction-retention=100"}, cfg.Args)
+
+	cfg = &Config{}
+	WithEtcdAutoCompaction("periodic", "30m")(cfg)
+	require.Equal(t, []string{"--embedded-etcd-auto-compaction-mode=periodic", "--embedded-etcd-auto-compaction-retention=30m"}, cfg.Args)
+
+	require.NotPanics(t, func() { WithEtcdAutoCompaction("periodic", "1") })
+	require.Panics(t, func() { WithEtcdAutoCompaction("hourly", "1") })
+	require.Panics(t, func() { WithEtcdAutoCompaction("revision", "10m") })
+	require.Panics(t, func() { WithEtcdAutoCompaction("periodic", "often") })
+}
+
+func TestWithGoawayChance(t *testing.T) {
+	cfg := &Config{}
+	WithGoawayChance(0.02)(cfg)
+	require.Equal(t, []string{"--goaway-chance=0.02"}, cfg.Args)
+
+	require.Panics(t, func() { WithGoawayChance(-0.01) })
+	require.Panics(t, func() { WithGoawayChance(0.5) })
+}
+
+func TestWithQuotaControllers(t *testing.T) {
+	cfg := &Config{}
+	WithCustomArguments("--run-controllers=false")(cfg)
+	WithQuotaControllers()(cfg)
+	require.Equal(t, []string{"--run-controllers=false", "--unsupported-run-individual-controllers=apibinder,quota,workspace-scheduler"}, cfg.Args)
+}
+
+func TestWithCORSAllowedOrigins(t *testing.T) {
+	cfg := &Config{}
+	WithCORSAllowedOrigins([]string{`//localhost(:|$)`, `//example\.com$`})(cfg)
+	require.Equal(t, []string{`--cors-allowed-origins=//localhost(:|$),//example\.com$`}, cfg.Args)
+
+	cfg = &Config{}
+	WithCORSAllowedOrigins(nil)(cfg)
+	require.Empty(t, cfg.Args)
+
+	require.Panics(t, func() { WithCORSAllowedOrigins([]string{""}) })
+	require.Panics(t, func() { WithCORSAllowedOrigins([]string{"a,b"}) })
+	require.Panics(t, func() { WithCORSAllowedOrigins([]string{"(unclosed"}) })
+}
+
+func TestWithEtcdPrefix(t *testing.T) {
+	cfg := &Config{}
+	WithEtcdPrefix("/kcp-a")(cfg)
+	WithEtcdPrefix("/e2e/kcp-b")(cfg)
+	require.Equal(t, []string{"--etcd-prefix=/kcp-a", "--etcd-prefix=/e2e/kcp-b"}, cfg.Args)
+
+	for _, prefix := range []string{"", "/", "kcp", "/kcp/", "//kcp", "/kcp/../other", "/./kcp", "/ kcp"} {
+		require.Panics(t, func() { WithEtcdPrefix(prefix) }, "prefix %q", prefix)
+	}
+}
+
+func TestWithAuthenticationConfig(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	valid := write("valid.yaml", `apiVersion: apiserver.config.k8s.io/v1beta1
+kind: AuthenticationConfiguration
+jwt:
+- issuer:
+    url: https://issuer.example.com
+    audiences: [kcp]
+  claimMappings:
+    username:
+      claim: sub
+      prefix: "oidc:"
+`)
+	cfg := &Config{}
+	WithAuthenticationConfig(valid)(cfg)
+	require.Equal(t, []string{"--authentication-config=" + valid}, cfg.Args)
+	require.Equal(t, map[string]bool{"StructuredAuthenticationConfiguration": true}, cfg.FeatureGates)
+
+	insecure := write("insecure.yaml", `apiVersion: apiserver.config.k8s.io/v1beta1
+kind: AuthenticationConfiguration
+jwt:
+- issuer:
+    url: http://issuer.example.com
+    audiences: [kcp]
+  claimMappings:
+    username:
+      claim: sub
+      prefix: ""
+`)
+	wrongKind := write("wrong-kind.yaml", `apiVersion: apiserver.config.k8s.io/v1beta1
+kind: AuthorizationConfiguration
+`)
+	require.Panics(t, func() { WithAuthenticationConfig(filepath.Join(dir, "missing.yaml")) })
+	require.Panics(t, func() { WithAuthenticationConfig(insecure) })
+	require.Panics(t, func() { WithAuthenticationConfig(wrongKind) })
+}
+
+func TestWithRequestHeaderAllowedNames(t *testing.T) {
+	cfg := &Config{}
+	WithRequestHeaderAllowedNames([]string{"kcp-front-proxy", "other-proxy"})(cfg)
+	require.Equal(t, []string{"--requestheader-allowed-names=kcp-front-proxy,other-proxy"}, cfg.Args)
+
+	cfg = &Config{}
+	WithRequestHeaderAllowedNames(nil)(cfg)
+	require.Empty(t, cfg.Args)
+
+	require.Panics(t, func() { WithRequestHeaderAllowedNames([]string{""}) })
+	require.Panics(t, func() { WithRequestHeaderAllowedNames([]string{"a,b"}) })
+}
+
+func TestWithRootWorkspaceType(t *testing.T) {
+	cfg := &Config{}
+	WithRootWorkspaceType("universal")(cfg)
+	require.Len(t, cfg.ReadinessHooks, 1)
+	require.Empty(t, cfg.Args)
+
+	require.Panics(t, func() { WithRootWorkspaceType("") })
+	require.Panics(t, func() { WithRootWorkspaceType("root:universal") })
+}
+
+func TestWithReplicationControllers(t *testing.T) {
+	cfg := &Config{}
+	WithCustomArguments("--run-controllers=false")(cfg)
+	WithReplicationControllers()(cfg)
+	require.Len(t, cfg.Args, 2)
+	require.Equal(t, "--run-controllers=false", cfg.Args[0])
+	require.Contains(t, cfg.Args[1], "--unsupported-run-individual-controllers=apisreplicateclusterrole,")
+}
+
+func TestWithExternalURL(t *testing.T) {
+	cfg := &Config{}
+	WithExternalURLScheme("http")(cfg)
+	WithExternalURLPathPrefix("/kcp")(cfg)
+	require.Equal(t, "http", cfg.ExternalURLScheme)
+	require.Equal(t, "/kcp", cfg.ExternalURLPathPrefix)
+	require.Empty(t, cfg.Args)
+
+	require.Panics(t, func() { WithExternalURLScheme("ftp") })
+	require.Panics(t, func() { WithExternalURLPathPrefix("kcp") })
+	require.Panics(t, func() { WithExternalURLPathPrefix("/kcp/") })
+}
+
+func TestWithLogWriter(t *testing.T) {
+	cfg := &Config{}
+	var buf bytes.Buffer
+	WithLogWriter(&buf)(cfg)
+	require.Same(t, &buf, cfg.LogWriter)
+	require.Empty(t, cfg.Args)
+
+	require.Panics(t, func() { WithLogWriter(nil) })
+}
+
+func TestWithFeatureGates(t *testing.T) {
+	cfg := &Config{}
+	WithFeatureGates(map[string]bool{"WorkspaceMounts": true, "OpenAPIEnums": true})(cfg)
+	WithFeatureGates(map[string]bool{"OpenAPIEnums": false})(cfg)
+	require.Equal(t, map[string]bool{"WorkspaceMounts": true, "OpenAPIEnums": false}, cfg.FeatureGates)
+	require.Empty(t, cfg.Args)
+
+	require.Panics(t, func() { WithFeatureGates(map[string]bool{"": true}) })
+}
+
+func TestWithCustomArguments(t *testing.T) {
+	cfg := &Config{}
+	WithServerTiming()(cfg)
+	WithCustomArguments("--foo=bar")(cfg)
+	WithCustomArguments("--baz", "qux")(cfg)
+	require.Equal(t, []string{"--server-timing", "--foo=bar", "--baz", "qux"}, cfg.Args)
+}
+
+func TestWithReplacedArguments(t *testing.T) {
+	cfg := &Config{}
+	WithServerTiming()(cfg)
+	WithCustomArguments("--foo=bar")(cfg)
+	WithReplacedArguments("--baz", "qux")(cfg)
+	require.Equal(t, []string{"--baz", "qux"}, cfg.Args)
+
+	WithReplacedArguments()(cfg)
+	require.Empty(t, cfg.Args)
+}
+
+func TestWithShards(t *testing.T) {
+	cfg := &Config{}
+	WithShards(3)(cfg)
+	require.Equal(t, 3, cfg.Shards)
+	require.Empty(t, cfg.Args)
+
+	require.Panics(t, func() { WithShards(0) })
+}
+
+func TestWithReadinessTimeout(t *testing.T) {
+	cfg := &Config{}
+	WithReadinessTimeout(5 * time.Minute)(cfg)
+	require.Equal(t, 5*time.Minute, cfg.ReadinessTimeout)
+	require.Empty(t, cfg.Args)
+
+	require.Panics(t, func() { WithReadinessTimeout(0) })
+}
+
+func TestWithEnv(t *testing.T) {
+	cfg := &Config{}
+	WithEnv(map[string]string{"FOO": "bar", "BAZ": "qux"})(cfg)
+	WithEnv(map[string]string{"FOO": "override"})(cfg)
+	require.Equal(t, map[string]string{"FOO": "override", "BAZ": "qux"}, cfg.Env)
+	require.Empty(t, cfg.Args)
+
+	require.Panics(t, func() { WithEnv(map[string]string{"": "bar"}) })
+	require.Panics(t, func() { WithEnv(map[string]string{"FOO=BAR": "baz"}) })
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned"
+)
+
+// EtcdSizeSample is the size of the etcd database of a server at a point in
+// time, as reported by apiserver_storage_size_bytes.
+type EtcdSizeSample struct {
+	Time  time.Time
+	Bytes int64
+}
+
+// etcdSizeFile is the artifact the samples are written to, one
+// "<RFC 3339 time>,<bytes>" line per sample.
+const etcdSizeFile = "etcd-size.csv"
+
+// startEtcdSizeSampling samples the etcd database size every
+// cfg.EtcdSizeSamplingInterval until the test ends.
+func (c *kcpServer) startEtcdSizeSampling(t TestingT) error {
+	f, err := os.Create(filepath.Join(c.cfg.ArtifactDir, etcdSizeFile))
+	if err != nil {
+		return fmt.Errorf("could not create etcd size file: %w", err)
+	}
+	if _, err := fmt.Fprintln(f, "time,bytes"); err != nil {
+		f.Close()
+		return fmt.Errorf("could not write etcd size file: %w", err)
+	}
+
+	cfg := c.RootShardSystemMasterBaseConfig(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wait.UntilWithContext(ctx, func(ctx context.Context) {
+			size, err := etcdSize(ctx, cfg)
+			if err != nil {
+				if ctx.Err() == nil {
+					t.Logf("error sampling etcd size of server %s: %v", c.Name(), err)
+				}
+				return
+			}
+			sample := EtcdSizeSample{Time: time.Now(), Bytes: size}
+
+			c.lock.Lock()
+			c.etcdSizeSamples = append(c.etcdSizeSamples, sample)
+			c.lock.Unlock()
+
+			if _, err := fmt.Fprintf(f, "%s,%d\n", sample.Time.Format(time.RFC3339Nano), sample.Bytes); err != nil {
+				t.Logf("error writing etcd size of server %s: %v", c.Name(), err)
+			}
+		}, c.cfg.EtcdSizeSamplingInterval)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		f.Close()
+	})
+	return nil
+}
+
+// etcdSize returns the largest apiserver_storage_size_bytes of the server
+// behind cfg. There is one series per etcd cluster, and all of them are the
+// same etcd unless kcp is configured with etcd overrides.
+func etcdSize(ctx context.Context, cfg *rest.Config) (int64, error) {
+	client, err := kcpclientset.NewForConfig(cfg)
+	if err != nil {
+		return 0, err
+	}
+	raw, err := client.RESTClient().Get().RequestURI("/metrics").SetHeader("Accept", string(expfmt.NewFormat(expfmt.TypeTextPlain))).DoRaw(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(raw))
+	if err != nil {
+		return 0, err
+	}
+	family, found := families["apiserver_storage_size_bytes"]
+	if !found || len(family.GetMetric()) == 0 {
+		return 0, fmt.Errorf("apiserver_storage_size_bytes not found")
+	}
+	var size int64
+	for _, m := range family.GetMetric() {
+		size = max(size, int64(m.GetGauge().GetValue()))
+	}
+	return size, nil
+}
+
+// EtcdSizeSamples returns the etcd database sizes of server sampled so far,
+// oldest first. It is empty unless the server was started by the fixture with
+// WithEtcdSizeSampling.
+func EtcdSizeSamples(server RunningServer) []EtcdSizeSample {
+	s, ok := server.(*kcpServer)
+	if !ok {
+		return nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return append([]EtcdSizeSample(nil), s.etcdSizeSamples...)
+}
@@ -18,6 +18,7 @@ package server
 
 import (
 	"bytes"
+	"context"
 	cryptorand "crypto/rand"
 	"crypto/rsa"
 	"crypto/tls"
@@ -156,7 +157,7 @@ func (s *externalKCPServer) ShardNames() []string {
 
 func (s *externalKCPServer) Artifact(t TestingT, producer func() (runtime.Object, error)) {
 	t.Helper()
-	artifact(t, s, producer)
+	artifact(t, filepath.Join("artifacts", "kcp", s.Name()), producer)
 }
 
 // Stop is a noop to satisfy the RunningServer interface.
@@ -169,6 +170,22 @@ func (s *externalKCPServer) Stopped() bool {
 	return false
 }
 
+func (s *externalKCPServer) EffectiveFeatureGates(ctx context.Context) (map[string]bool, error) {
+	cfg, found := s.shardCfgs[corev1alpha1.RootShard]
+	if !found {
+		return nil, fmt.Errorf("kubeconfig for shard %q not found", corev1alpha1.RootShard)
+	}
+	raw, err := cfg.RawConfig()
+	if err != nil {
+		return nil, err
+	}
+	restConfig, err := clientcmd.NewNonInteractiveClientConfig(raw, "shard-base", nil, nil).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	return effectiveFeatureGates(ctx, restConfig)
+}
+
 // LoadKubeConfig loads a kubeconfig from disk. This method is
 // intended to be common between fixture for servers whose lifecycle
 // is test-managed and fixture for servers whose lifecycle is managed
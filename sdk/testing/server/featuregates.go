@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+var (
+	featureEnabledSample = regexp.MustCompile(`^kubernetes_feature_enabled\{(.*)\} (\S+)$`)
+	featureNameLabel     = regexp.MustCompile(`(?:^|,)name="([^"]*)"`)
+)
+
+// EffectiveFeatureGates returns the state of every feature gate of the root
+// shard of server, as reported by its /metrics endpoint.
+func EffectiveFeatureGates(ctx context.Context, server RunningServer) (map[string]bool, error) {
+	switch s := server.(type) {
+	case *kcpServer:
+		return s.EffectiveFeatureGates(ctx)
+	case *externalKCPServer:
+		return s.EffectiveFeatureGates(ctx)
+	case *shardedServer:
+		return s.EffectiveFeatureGates(ctx)
+	default:
+		return nil, fmt.Errorf("feature gates of server %s are not known", server.Name())
+	}
+}
+
+// effectiveFeatureGates returns the state of every feature gate of the server
+// behind cfg, as reported by the kubernetes_feature_enabled gauge in /metrics.
+func effectiveFeatureGates(ctx context.Context, cfg *rest.Config) (map[string]bool, error) {
+	cfg = rest.CopyConfig(cfg)
+	if cfg.NegotiatedSerializer == nil {
+		cfg.NegotiatedSerializer = kubernetesscheme.Codecs.WithoutConversion()
+	}
+	client, err := rest.UnversionedRESTClientFor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create unversioned client: %w", err)
+	}
+
+	raw, err := client.Get().AbsPath("/metrics").DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metrics from %s: %w", cfg.Host, err)
+	}
+	gates, err := parseFeatureGates(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics from %s: %w", cfg.Host, err)
+	}
+	return gates, nil
+}
+
+func parseFeatureGates(metrics string) (map[string]bool, error) {
+	gates := map[string]bool{}
+	scanner := bufio.NewScanner(strings.NewReader(metrics))
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		match := featureEnabledSample.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		name := featureNameLabel.FindStringSubmatch(match[1])
+		if name == nil {
+			return nil, fmt.Errorf("feature sample without name: %s", scanner.Text())
+		}
+		value, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of feature %s: %w", name[1], err)
+		}
+		gates[name[1]] = value == 1
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(gates) == 0 {
+		return nil, fmt.Errorf("no kubernetes_feature_enabled metric found")
+	}
+	return gates, nil
+}
+
+// mergeFeatureGates merges the feature gates in the --feature-gates format,
+// e.g. "a=true,b=false", with overrides, which win on conflict. The result is
+// sorted by name and has every gate only once.
+func mergeFeatureGates(gates string, overrides map[string]bool) (string, error) {
+	merged := map[string]bool{}
+	for _, pair := range strings.Split(gates, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return "", fmt.Errorf("missing value of feature gate %q", name)
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return "", fmt.Errorf("invalid value of feature gate %s: %w", name, err)
+		}
+		merged[strings.TrimSpace(name)] = enabled
+	}
+	for name, enabled := range overrides {
+		merged[name] = enabled
+	}
+
+	names := make([]string, 0, len(merged))
+	for name := range merged {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%s=%t", name, merged[name]))
+	}
+	return strings.Join(pairs, ","), nil
+}
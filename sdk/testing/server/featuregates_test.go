@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFeatureGates(t *testing.T) {
+	gates, err := parseFeatureGates(`# HELP kubernetes_feature_enabled [BETA] This metric records the data about the stage and enablement of a k8s feature.
+# TYPE kubernetes_feature_enabled gauge
+kubernetes_feature_enabled{name="WorkspaceMounts",stage="ALPHA"} 1
+kubernetes_feature_enabled{name="OpenAPIEnums",stage="BETA"} 0
+go_goroutines 42
+`)
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"WorkspaceMounts": true, "OpenAPIEnums": false}, gates)
+
+	_, err = parseFeatureGates("go_goroutines 42\n")
+	require.Error(t, err, "metrics without feature gates should fail")
+}
+
+func TestMergeFeatureGates(t *testing.T) {
+	gates, err := mergeFeatureGates("WorkspaceMounts=false, OpenAPIEnums=true,", map[string]bool{
+		"WorkspaceMounts":        true,
+		"APIResponseCompression": false,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "APIResponseCompression=false,OpenAPIEnums=true,WorkspaceMounts=true", gates)
+
+	gates, err = mergeFeatureGates("", nil)
+	require.NoError(t, err)
+	require.Empty(t, gates)
+
+	_, err = mergeFeatureGates("WorkspaceMounts", nil)
+	require.Error(t, err, "gate without value should fail")
+	_, err = mergeFeatureGates("WorkspaceMounts=maybe", nil)
+	require.Error(t, err, "gate with invalid value should fail")
+}
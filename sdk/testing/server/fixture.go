@@ -20,12 +20,14 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
+	goruntime "runtime"
 	"runtime/debug"
 	"strconv"
 	"strings"
@@ -40,6 +42,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
@@ -47,6 +50,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
+	kcpclienthelper "github.com/kcp-dev/apimachinery/v2/pkg/client"
 	"github.com/kcp-dev/logicalcluster/v3"
 
 	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
@@ -80,6 +84,20 @@ type Fixture = map[string]RunningServer
 func NewFixture(t TestingT, cfgs ...Config) Fixture {
 	t.Helper()
 
+	// Registered before anything else, so it runs after all other cleanups
+	// of the fixture.
+	for _, cfg := range cfgs {
+		if cfg.StartupLeakCheck {
+			checkLeaks := startupLeakCheck()
+			t.Cleanup(func() {
+				if err := checkLeaks(); err != nil {
+					t.Errorf("kcp fixture leaked goroutines: %v", err)
+				}
+			})
+			break
+		}
+	}
+
 	// Initialize servers from the provided configuration
 	servers := make([]*kcpServer, 0, len(cfgs))
 	ret := make(Fixture, len(cfgs))
@@ -90,6 +108,12 @@ func NewFixture(t TestingT, cfgs ...Config) Fixture {
 		if len(cfg.DataDir) == 0 {
 			panic(fmt.Sprintf("provided kcpConfig for %s is incorrect, missing DataDir", cfg.Name))
 		}
+		if cfg.Shards > 1 {
+			srv, err := startShardedServer(t, cfg)
+			require.NoError(t, err)
+			ret[srv.Name()] = srv
+			continue
+		}
 		srv, err := newKcpServer(t, cfg)
 		require.NoError(t, err)
 
@@ -103,7 +127,8 @@ func NewFixture(t TestingT, cfgs ...Config) Fixture {
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
 	g, ctx := errgroup.WithContext(ctx)
-	for i, srv := range servers {
+	for _, srv := range servers {
+		srvStart := time.Now()
 		err := srv.Run(t)
 		require.NoError(t, err)
 
@@ -119,16 +144,35 @@ func NewFixture(t TestingT, cfgs ...Config) Fixture {
 
 			rootCfg := srv.RootShardSystemMasterBaseConfig(t)
 			t.Logf("Waiting for readiness for server at %s", rootCfg.Host)
-			if err := WaitForReady(ctx, rootCfg); err != nil {
+			readyCtx, readyCancel := context.WithTimeout(ctx, srv.readinessTimeout)
+			defer readyCancel()
+			if err := waitForReady(readyCtx, rootCfg, srv.readinessTimeout); err != nil {
 				cancel()
 				return err
 			}
 
-			if !cfgs[i].RunInProcess {
+			if srv.virtualWorkspaces != nil {
+				t.Logf("Starting virtual-workspaces server for server %s", srv.Name())
+				if err := srv.startVirtualWorkspaces(t); err != nil {
+					cancel()
+					return err
+				}
+			}
+
+			for j, hook := range srv.cfg.ReadinessHooks {
+				if err := hook(ctx, rest.CopyConfig(rootCfg)); err != nil {
+					cancel()
+					return fmt.Errorf("readiness hook %d for server %s failed: %w", j, srv.Name(), err)
+				}
+			}
+
+			if !srv.cfg.RunInProcess {
 				rootCfg := srv.RootShardSystemMasterBaseConfig(t)
 				MonitorEndpoints(t, rootCfg, "/livez", "/readyz")
 			}
 
+			srv.setStartupDuration(t, time.Since(srvStart))
+
 			return nil
 		})
 	}
@@ -137,6 +181,9 @@ func NewFixture(t TestingT, cfgs ...Config) Fixture {
 
 	for _, s := range servers {
 		scrapeMetricsForServer(t, s)
+		if s.cfg.EtcdSizeSamplingInterval > 0 {
+			require.NoError(t, s.startEtcdSizeSampling(t), "failed to start etcd size sampling")
+		}
 	}
 
 	if t.Failed() {
@@ -150,7 +197,7 @@ func NewFixture(t TestingT, cfgs ...Config) Fixture {
 
 		for _, s := range servers {
 			t.Log("Gathering metrics for kcp server", s.Name())
-			gatherMetrics(ctx, t, s, s.cfg.ArtifactDir)
+			gatherMetrics(ctx, t, s.Name(), s.RootShardSystemMasterBaseConfig(t), s.cfg.ArtifactDir, s.cfg.OpenMetricsArtifacts)
 		}
 	})
 
@@ -159,6 +206,11 @@ func NewFixture(t TestingT, cfgs ...Config) Fixture {
 	return ret
 }
 
+// defaultReadinessTimeout is how long the fixture waits for a server to write
+// its admin kubeconfig and then to become ready, unless WithReadinessTimeout is
+// used.
+const defaultReadinessTimeout = 2 * time.Minute
+
 // kcpServer exposes a kcp invocation to a test and
 // ensures the following semantics:
 //   - the server will run only until the test deadline
@@ -170,26 +222,55 @@ type kcpServer struct {
 	clientCfg        clientcmd.ClientConfig
 	cancel           func()
 	shutdownComplete bool
+	startupDuration  time.Duration
+	readinessTimeout time.Duration
+
+	logicalClusterAdminKubeconfigPath string
+
+	virtualWorkspaces *virtualWorkspaces
+
+	etcdSizeSamples []EtcdSizeSample
 }
 
 func newKcpServer(t TestingT, cfg Config) (*kcpServer, error) {
 	t.Helper()
 
 	s := &kcpServer{
-		cfg:  cfg,
-		lock: &sync.Mutex{},
+		cfg:              cfg,
+		lock:             &sync.Mutex{},
+		readinessTimeout: defaultReadinessTimeout,
+	}
+	if cfg.ReadinessTimeout > 0 {
+		s.readinessTimeout = cfg.ReadinessTimeout
 	}
 
-	s.cfg.ArtifactDir = filepath.Join(s.cfg.ArtifactDir, "kcp", cfg.Name)
+	s.cfg.ArtifactDir = filepath.Join(s.cfg.ArtifactDir, "kcp", cfg.Name, cfg.ArtifactSubdir)
 	if err := os.MkdirAll(s.cfg.ArtifactDir, 0755); err != nil {
 		return nil, fmt.Errorf("could not create artifact dir: %w", err)
 	}
 
+	if cfg.DataDirOnTmpfs {
+		dir, err := tmpfsDir(t)
+		if err != nil {
+			return nil, err
+		}
+		if dir != "" {
+			s.cfg.DataDir = dir
+		}
+	}
 	s.cfg.DataDir = filepath.Join(s.cfg.DataDir, "kcp", cfg.Name)
 	if err := os.MkdirAll(s.cfg.DataDir, 0755); err != nil {
 		return nil, fmt.Errorf("could not create data dir: %w", err)
 	}
 
+	if cfg.EtcdTracing {
+		arg, err := startTraceCollector(t, s.cfg.DataDir, filepath.Join(s.cfg.ArtifactDir, "traces.jsonl"))
+		if err != nil {
+			return nil, err
+		}
+		s.cfg.Args = append([]string{arg}, s.cfg.Args...)
+	}
+
 	kcpListenPort, err := GetFreePort(t)
 	if err != nil {
 		return nil, err
@@ -203,6 +284,45 @@ func newKcpServer(t TestingT, cfg Config) (*kcpServer, error) {
 		return nil, err
 	}
 
+	if cfg.ExternalURLScheme != "" || cfg.ExternalURLPathPrefix != "" {
+		scheme := cfg.ExternalURLScheme
+		if scheme == "" {
+			scheme = "https"
+		}
+		externalURL := scheme + "://localhost:" + kcpListenPort + cfg.ExternalURLPathPrefix
+		s.cfg.Args = append([]string{"--shard-external-url=" + externalURL}, s.cfg.Args...)
+		if !cfg.ExternalVirtualWorkspaces {
+			s.cfg.Args = append([]string{"--shard-virtual-workspace-url=" + externalURL}, s.cfg.Args...)
+		}
+	}
+
+	if cfg.LogicalClusterAdminKubeconfig {
+		path, args, err := writeLogicalClusterAdminKubeconfig(s.cfg.DataDir, kcpListenPort)
+		if err != nil {
+			return nil, err
+		}
+		s.logicalClusterAdminKubeconfigPath = path
+		// kcp requires an external URL with a logical-cluster-admin kubeconfig.
+		if cfg.ExternalURLScheme == "" && cfg.ExternalURLPathPrefix == "" {
+			args = append(args, "--shard-external-url=https://localhost:"+kcpListenPort)
+		}
+		s.cfg.Args = append(args, s.cfg.Args...)
+	}
+
+	if cfg.ExternalVirtualWorkspaces {
+		vw, args, err := newVirtualWorkspaces(t, s.cfg.DataDir)
+		if err != nil {
+			return nil, err
+		}
+		s.virtualWorkspaces = vw
+		s.cfg.Args = append(args, s.cfg.Args...)
+	}
+
+	featureGates, err := mergeFeatureGates(fmt.Sprintf("%s", utilfeature.DefaultFeatureGate), cfg.FeatureGates)
+	if err != nil {
+		return nil, fmt.Errorf("invalid feature gates: %w", err)
+	}
+
 	s.cfg.Args = append(
 		[]string{
 			"--root-directory",
@@ -212,7 +332,7 @@ func newKcpServer(t TestingT, cfg Config) (*kcpServer, error) {
 			"--embedded-etcd-peer-port=" + etcdPeerPort,
 			"--embedded-etcd-wal-size-bytes=" + strconv.Itoa(5*1000), // 5KB
 			"--kubeconfig-path=" + s.KubeconfigPath(),
-			"--feature-gates=" + fmt.Sprintf("%s", utilfeature.DefaultFeatureGate),
+			"--feature-gates=" + featureGates,
 			"--audit-log-path", filepath.Join(s.cfg.ArtifactDir, "kcp.audit"),
 			"--v=4",
 		},
@@ -222,6 +342,30 @@ func newKcpServer(t TestingT, cfg Config) (*kcpServer, error) {
 	return s, nil
 }
 
+// tmpfsRoot is where tmpfs is mounted on Linux.
+const tmpfsRoot = "/dev/shm"
+
+// tmpfsDir creates a directory on tmpfs that is removed when the test ends. It
+// returns an empty string if tmpfs is not available on this platform.
+func tmpfsDir(t TestingT) (string, error) {
+	if goruntime.GOOS != "linux" {
+		return "", nil
+	}
+	if info, err := os.Stat(tmpfsRoot); err != nil || !info.IsDir() {
+		return "", nil //nolint:nilerr // fall back to the regular data directory
+	}
+	dir, err := os.MkdirTemp(tmpfsRoot, "kcp-")
+	if err != nil {
+		return "", fmt.Errorf("could not create data dir on tmpfs: %w", err)
+	}
+	t.Cleanup(func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Logf("failed to remove data dir %s: %v", dir, err)
+		}
+	})
+	return dir, nil
+}
+
 // StartKcpCommand returns the string tokens required to start kcp in
 // the currently configured mode (direct or via `go run`).
 func StartKcpCommand(identity string) []string {
@@ -284,6 +428,11 @@ func (c *kcpServer) Run(t TestingT) error {
 		return fmt.Errorf("runner is nil")
 	}
 
+	if c.cfg.RunInProcess && c.cfg.GOMAXPROCS > 0 {
+		previous := goruntime.GOMAXPROCS(c.cfg.GOMAXPROCS)
+		t.Cleanup(func() { goruntime.GOMAXPROCS(previous) })
+	}
+
 	ctx, ctxCancel := context.WithCancel(context.Background())
 
 	shutdownComplete, err := runner(ctx, t, c.cfg)
@@ -309,14 +458,110 @@ func (c *kcpServer) Run(t TestingT) error {
 	return nil
 }
 
-func (c *kcpServer) Stop() {
+// startVirtualWorkspaces starts the external virtual-workspaces server of a
+// ready kcp server. It is stopped before kcp.
+func (c *kcpServer) startVirtualWorkspaces(t TestingT) error {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	shutdownComplete, err := c.virtualWorkspaces.start(ctx, t, c)
+	if err != nil {
+		ctxCancel()
+		if shutdownComplete != nil {
+			<-shutdownComplete
+		}
+		return err
+	}
+
+	stopVirtualWorkspaces := func() {
+		ctxCancel()
+		<-shutdownComplete
+	}
+	t.Cleanup(stopVirtualWorkspaces)
+
 	c.lock.Lock()
 	defer c.lock.Unlock()
+	stopKcp := c.cancel
+	c.cancel = func() {
+		stopVirtualWorkspaces()
+		stopKcp()
+	}
+	return nil
+}
+
+// RunOption modifies the configuration of a server before Restart runs it
+// again, e.g. to append arguments whose effect after a restart is tested.
+type RunOption func(cfg *Config)
+
+// Restart stops server, waits for it to shut down, and runs it again with the
+// same data directory and ports, returning once it is ready. A server with
+// multiple shards is restarted shard by shard, and opts must be empty. Servers
+// not started by the fixture cannot be restarted.
+func Restart(t TestingT, server RunningServer, opts ...RunOption) error {
+	t.Helper()
+
+	switch s := server.(type) {
+	case *kcpServer:
+		return s.Restart(t, opts...)
+	case *shardedServer:
+		return s.Restart(t, opts...)
+	default:
+		return fmt.Errorf("server %s cannot be restarted", server.Name())
+	}
+}
+
+// Restart stops the server, waits for it to shut down, and runs it again with
+// the same data directory, ports and arguments, modified by opts. It returns
+// once the server is ready again. Clients created before the restart keep
+// working.
+func (c *kcpServer) Restart(t TestingT, opts ...RunOption) error {
+	t.Helper()
+
+	if c.cfg.RunInProcess && RunInProcessFunc != nil {
+		return fmt.Errorf("server %s cannot be restarted: RunInProcessFunc does not support stopping, use ContextRunInProcessFunc", c.Name())
+	}
+
+	t.Logf("Stopping server %s for restart", c.Name())
+	c.Stop()
+
+	c.lock.Lock()
+	for _, opt := range opts {
+		opt(&c.cfg)
+	}
+	c.shutdownComplete = false
+	c.lock.Unlock()
+
+	t.Logf("Starting server %s again", c.Name())
+	if err := c.Run(t); err != nil {
+		return fmt.Errorf("failed to restart server %s: %w", c.Name(), err)
+	}
+
+	if err := c.loadCfg(context.Background()); err != nil {
+		return err
+	}
+	readyCtx, readyCancel := context.WithTimeout(context.Background(), c.readinessTimeout)
+	defer readyCancel()
+	if err := waitForReady(readyCtx, c.RootShardSystemMasterBaseConfig(t), c.readinessTimeout); err != nil {
+		return err
+	}
+	if c.virtualWorkspaces != nil {
+		if err := c.startVirtualWorkspaces(t); err != nil {
+			return err
+		}
+	}
+
+	t.Logf("Server %s is ready again", c.Name())
+	return nil
+}
+
+func (c *kcpServer) Stop() {
+	c.lock.Lock()
+	cancel := c.cancel
+	c.lock.Unlock()
 
-	if c.cancel == nil {
+	// cancel takes the lock itself once shutdown completed
+	if cancel == nil {
 		return
 	}
-	c.cancel()
+	cancel()
 }
 
 func (c *kcpServer) Stopped() bool {
@@ -325,8 +570,93 @@ func (c *kcpServer) Stopped() bool {
 	return c.shutdownComplete
 }
 
+// StartupDuration returns how long the server took from being started until
+// it was ready, including readiness hooks.
+func (c *kcpServer) StartupDuration() time.Duration {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.startupDuration
+}
+
+// StartupDuration returns how long server took from being started by the
+// fixture until it was ready, including readiness hooks. It is zero for
+// servers not started by the fixture.
+func StartupDuration(server RunningServer) time.Duration {
+	switch s := server.(type) {
+	case *kcpServer:
+		return s.StartupDuration()
+	case *shardedServer:
+		return s.StartupDuration()
+	default:
+		return 0
+	}
+}
+
+// setStartupDuration records the startup duration of the server and writes it
+// to the startup.json artifact, so that CI can trend it.
+func (c *kcpServer) setStartupDuration(t TestingT, d time.Duration) {
+	c.lock.Lock()
+	c.startupDuration = d
+	c.lock.Unlock()
+
+	writeStartupDuration(t, c.Name(), c.cfg.ArtifactDir, d)
+}
+
+// writeStartupDuration logs the startup duration d of the server called name
+// and writes it to the startup.json artifact in directory.
+func writeStartupDuration(t TestingT, name, directory string, d time.Duration) {
+	t.Logf("Server %s became ready after %s", name, d)
+	bs, err := json.Marshal(map[string]any{
+		"name":                   name,
+		"startupDurationSeconds": d.Seconds(),
+	})
+	if err == nil {
+		err = os.WriteFile(filepath.Join(directory, "startup.json"), bs, 0644)
+	}
+	if err != nil {
+		t.Logf("failed to write startup duration artifact for server %s: %v", name, err)
+	}
+}
+
+func (c *kcpServer) EffectiveFeatureGates(ctx context.Context) (map[string]bool, error) {
+	cfg, err := c.config("shard-base")
+	if err != nil {
+		return nil, err
+	}
+	return effectiveFeatureGates(ctx, cfg)
+}
+
+// AdminConfigForCluster returns a copy of the base config of server, i.e. with
+// the admin identity of its "base" context, scoped to the given logical cluster
+// path for clients that are not cluster-aware. Client-side throttling is
+// disabled (QPS=-1).
+func AdminConfigForCluster(t TestingT, server RunningServer, clusterPath logicalcluster.Path) *rest.Config {
+	t.Helper()
+
+	return kcpclienthelper.SetCluster(server.BaseConfig(t), clusterPath)
+}
+
+// AccessLogPath returns the path of the HTTP request log of server, or an empty
+// string if it was not started by the fixture with WithAccessLog.
+func AccessLogPath(server RunningServer) string {
+	s, ok := server.(*kcpServer)
+	if !ok || s.cfg.AccessLogPath == "" {
+		return ""
+	}
+	return filepath.Join(s.cfg.ArtifactDir, s.cfg.AccessLogPath)
+}
+
 func runExternal(ctx context.Context, t TestingT, cfg Config) (<-chan struct{}, error) {
-	commandLine := append(StartKcpCommand("KCP"), cfg.Args...)
+	return runExecutable(ctx, t, "kcp", "KCP", []string{"start"}, cfg)
+}
+
+// runExecutable runs the given executable of the kcp repository with the
+// subcommand and cfg.Args until ctx is done, resolving it the same way as
+// Command. On shutdown the whole process group receives SIGTERM. Output is
+// written to <executable>.log in the artifact directory.
+func runExecutable(ctx context.Context, t TestingT, executable, identity string, subcommand []string, cfg Config) (<-chan struct{}, error) {
+	commandLine := append(Command(executable, identity), subcommand...)
+	commandLine = append(commandLine, cfg.Args...)
 
 	t.Logf("running: %v", strings.Join(commandLine, " "))
 
@@ -342,7 +672,19 @@ func runExternal(ctx context.Context, t TestingT, cfg Config) (<-chan struct{},
 	// the idea!
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
-	logFile, err := os.Create(filepath.Join(cfg.ArtifactDir, "kcp.log"))
+	if len(cfg.Env) > 0 || cfg.GOMAXPROCS > 0 {
+		// later entries win, so anything set here overrides the environment
+		// of the test process
+		cmd.Env = os.Environ()
+		for _, name := range sets.List(sets.KeySet(cfg.Env)) {
+			cmd.Env = append(cmd.Env, name+"="+cfg.Env[name])
+		}
+		if cfg.GOMAXPROCS > 0 {
+			cmd.Env = append(cmd.Env, fmt.Sprintf("GOMAXPROCS=%d", cfg.GOMAXPROCS))
+		}
+	}
+
+	logFile, err := os.Create(filepath.Join(cfg.ArtifactDir, executable+".log"))
 	if err != nil {
 		return nil, fmt.Errorf("could not create log file: %w", err)
 	}
@@ -359,19 +701,42 @@ func runExternal(ctx context.Context, t TestingT, cfg Config) (<-chan struct{},
 	writers := []io.Writer{&log, logFile}
 
 	if cfg.LogToConsole {
-		prefix := fmt.Sprintf("%s: ", t.Name())
+		// prefix every line with the server name, so the output of servers running
+		// concurrently in the same test can be told apart. With WithDefaultsFrom the
+		// server is named after the test already.
+		name := cfg.Name
+		if name == "" {
+			name = executable
+		}
+		prefix := fmt.Sprintf("%s/%s: ", t.Name(), name)
+		if name == t.Name() {
+			prefix = name + ": "
+		}
 		writers = append(writers, prefixer.New(os.Stdout, func() string { return prefix }))
 	}
 
+	if cfg.LogWriter != nil {
+		writers = append(writers, cfg.LogWriter)
+	}
+
+	if cfg.AccessLogPath != "" {
+		accessLog, err := newAccessLogWriter(filepath.Join(cfg.ArtifactDir, cfg.AccessLogPath), maxAccessLogSize)
+		if err != nil {
+			return nil, err
+		}
+		t.Cleanup(accessLog.Close)
+		writers = append(writers, accessLog)
+	}
+
 	mw := io.MultiWriter(writers...)
 	cmd.Stdout = mw
 	cmd.Stderr = mw
 
 	if err := cmd.Start(); err != nil {
-		if os.Getenv(kcpBinariesDirEnvDir) == "" && commandLine[0] == "kcp" {
-			t.Log("Consider setting KCP_BINARIES_DIR pointing to a directory with a kcp binary.")
+		if os.Getenv(kcpBinariesDirEnvDir) == "" && commandLine[0] == executable {
+			t.Logf("Consider setting KCP_BINARIES_DIR pointing to a directory with a %s binary.", executable)
 		}
-		return nil, fmt.Errorf("failed to start kcp: %w", err)
+		return nil, fmt.Errorf("failed to start %s: %w", executable, err)
 	}
 
 	go func() {
@@ -380,7 +745,7 @@ func runExternal(ctx context.Context, t TestingT, cfg Config) (<-chan struct{},
 			// Ensure child process is killed on cleanup - send the negative of the pid, which is the process group id.
 			// See https://medium.com/@felixge/killing-a-child-process-and-all-of-its-children-in-go-54079af94773 for details.
 			if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM); err != nil {
-				t.Errorf("Saw an error trying to kill `kcp`: %v", err)
+				t.Errorf("Saw an error trying to kill `%s`: %v", executable, err)
 			}
 		}
 	}()
@@ -395,8 +760,8 @@ func runExternal(ctx context.Context, t TestingT, cfg Config) (<-chan struct{},
 			// we care about errors in the process that did not result from the
 			// context expiring and us ending the process
 			data := filterKcpLogs(t, &log)
-			t.Errorf("`kcp` failed: %v logs:\n%v", err, data)
-			t.Errorf("`kcp` failed: %v", err)
+			t.Errorf("`%s` failed: %v logs:\n%v", executable, err, data)
+			t.Errorf("`%s` failed: %v", executable, err)
 		}
 	}()
 
@@ -517,7 +882,7 @@ func (c *kcpServer) RawConfig() (clientcmdapi.Config, error) {
 
 func (c *kcpServer) loadCfg(ctx context.Context) error {
 	var lastError error
-	if err := wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, 2*time.Minute, true, func(ctx context.Context) (bool, error) {
+	if err := wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, c.readinessTimeout, true, func(ctx context.Context) (bool, error) {
 		if c.Stopped() {
 			return false, fmt.Errorf("failed to load admin kubeconfig: server has stopped")
 		}
@@ -554,15 +919,15 @@ func (c *kcpServer) CADirectory() string {
 
 func (c *kcpServer) Artifact(t TestingT, producer func() (runtime.Object, error)) {
 	t.Helper()
-	artifact(t, c, producer)
+	artifact(t, filepath.Join("artifacts", "kcp", c.Name(), c.cfg.ArtifactSubdir), producer)
 }
 
 // artifact registers the data-producing function to run and dump the YAML-formatted output
-// to the artifact directory for the test before the kcp process is terminated.
-func artifact(t TestingT, server RunningServer, producer func() (runtime.Object, error)) {
+// to the given subdirectory of the artifact directory for the test before the kcp process
+// is terminated.
+func artifact(t TestingT, subDir string, producer func() (runtime.Object, error)) {
 	t.Helper()
 
-	subDir := filepath.Join("artifacts", "kcp", server.Name())
 	artifactDir, err := createTempDirForTest(t, subDir)
 	require.NoError(t, err, "could not create artifacts dir")
 	// Using t.Cleanup ensures that artifact collection is local to
@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestRunExecutable(t *testing.T) {
+	binDir := t.TempDir()
+	script := `#!/bin/sh
+trap 'echo terminated; exit 0' TERM
+echo "started $@"
+while true; do sleep 0.1; done
+`
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "trivial"), []byte(script), 0755))
+	t.Setenv(kcpBinariesDirEnvDir, binDir)
+	t.Setenv("NO_GORUN", "true")
+
+	artifactDir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	shutdownComplete, err := runExecutable(ctx, t, "trivial", "TRIVIAL", []string{"serve"}, Config{
+		ArtifactDir: artifactDir,
+		Args:        []string{"--foo=bar"},
+	})
+	require.NoError(t, err)
+
+	logPath := filepath.Join(artifactDir, "trivial.log")
+	require.Eventually(t, func() bool {
+		logs, err := os.ReadFile(logPath)
+		return err == nil && strings.Contains(string(logs), "started serve --foo=bar")
+	}, 10*time.Second, 100*time.Millisecond, "executable did not start with subcommand and arguments")
+
+	cancel()
+	select {
+	case <-shutdownComplete:
+	case <-time.After(10 * time.Second):
+		t.Fatal("executable did not shut down after SIGTERM")
+	}
+	logs, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	require.Contains(t, string(logs), "terminated", "executable was not terminated gracefully")
+}
+
+func TestRunExecutableEnv(t *testing.T) {
+	binDir := t.TempDir()
+	script := `#!/bin/sh
+trap 'exit 0' TERM
+echo "env KCP_E2E_FOO=$KCP_E2E_FOO GOMAXPROCS=$GOMAXPROCS"
+while true; do sleep 0.1; done
+`
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "trivial"), []byte(script), 0755))
+	t.Setenv(kcpBinariesDirEnvDir, binDir)
+	t.Setenv("NO_GORUN", "true")
+	t.Setenv("KCP_E2E_FOO", "inherited")
+
+	cfg := Config{ArtifactDir: t.TempDir()}
+	WithEnv(map[string]string{"KCP_E2E_FOO": "bar"})(&cfg)
+	WithGOMAXPROCS(2)(&cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	shutdownComplete, err := runExecutable(ctx, t, "trivial", "TRIVIAL", nil, cfg)
+	require.NoError(t, err)
+
+	logPath := filepath.Join(cfg.ArtifactDir, "trivial.log")
+	require.Eventually(t, func() bool {
+		logs, err := os.ReadFile(logPath)
+		return err == nil && strings.Contains(string(logs), "env KCP_E2E_FOO=bar GOMAXPROCS=2")
+	}, 10*time.Second, 100*time.Millisecond, "environment variables were not passed to the executable")
+
+	cancel()
+	<-shutdownComplete
+}
+
+// TestRunExecutableLogToConsole replaces os.Stdout and must not run in parallel.
+func TestRunExecutableLogToConsole(t *testing.T) {
+	binDir := t.TempDir()
+	script := `#!/bin/sh
+trap 'exit 0' TERM
+echo "started"
+while true; do sleep 0.1; done
+`
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "trivial"), []byte(script), 0755))
+	t.Setenv(kcpBinariesDirEnvDir, binDir)
+	t.Setenv("NO_GORUN", "true")
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	t.Cleanup(func() { os.Stdout = stdout })
+
+	var lock sync.Mutex
+	var captured bytes.Buffer
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, err := r.Read(buf)
+			lock.Lock()
+			captured.Write(buf[:n])
+			lock.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var stopped []<-chan struct{}
+	for _, name := range []string{"first", "second", t.Name()} {
+		shutdownComplete, err := runExecutable(ctx, t, "trivial", "TRIVIAL", nil, Config{
+			Name:         name,
+			ArtifactDir:  t.TempDir(),
+			LogToConsole: true,
+		})
+		require.NoError(t, err)
+		stopped = append(stopped, shutdownComplete)
+	}
+
+	require.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		lines := strings.Split(captured.String(), "\n")
+		return slices.Contains(lines, t.Name()+"/first: started") && slices.Contains(lines, t.Name()+"/second: started") && slices.Contains(lines, t.Name()+": started")
+	}, 10*time.Second, 100*time.Millisecond, "output of the executables was not streamed with their name as prefix")
+	lock.Lock()
+	require.NotContains(t, captured.String(), t.Name()+"/"+t.Name(), "the test name was repeated for a server named after the test")
+	lock.Unlock()
+
+	cancel()
+	for _, shutdownComplete := range stopped {
+		<-shutdownComplete
+	}
+	require.NoError(t, w.Close())
+}
+
+func TestArtifactSubdir(t *testing.T) {
+	artifactRoot := t.TempDir()
+	t.Setenv("ARTIFACT_DIR", artifactRoot)
+
+	t.Run("servers", func(t *testing.T) {
+		for _, subdir := range []string{"first", "second"} {
+			cfg := Config{Name: "main"}
+			WithArtifactSubdir(subdir)(&cfg)
+			srv := &kcpServer{cfg: cfg}
+			srv.Artifact(t, func() (runtime.Object, error) {
+				return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "artifact"}}, nil
+			})
+		}
+	})
+
+	for _, subdir := range []string{"first", "second"} {
+		matches, err := filepath.Glob(filepath.Join(artifactRoot, "TestArtifactSubdir", "servers", "*", "artifacts", "kcp", "main", subdir, "core_ConfigMap-artifact.yaml"))
+		require.NoError(t, err)
+		require.Len(t, matches, 1, "artifact of server %q not found", subdir)
+	}
+
+	require.Panics(t, func() { WithArtifactSubdir("") })
+	require.Panics(t, func() { WithArtifactSubdir("a/b") })
+	require.Panics(t, func() { WithArtifactSubdir("..") })
+}
+
+func TestRootDirectoryOnTmpfs(t *testing.T) {
+	cfg := Config{}
+	WithDefaultsFrom(t)(&cfg)
+	WithRootDirectoryOnTmpfs()(&cfg)
+
+	srv, err := newKcpServer(t, cfg)
+	require.NoError(t, err)
+	require.Contains(t, srv.cfg.Args, srv.cfg.DataDir, "data dir is not passed as --root-directory")
+	require.DirExists(t, srv.cfg.DataDir)
+
+	if info, err := os.Stat(tmpfsRoot); goruntime.GOOS != "linux" || err != nil || !info.IsDir() {
+		require.True(t, strings.HasPrefix(srv.cfg.DataDir, cfg.DataDir), "data dir %s should fall back to %s without tmpfs", srv.cfg.DataDir, cfg.DataDir)
+		return
+	}
+	require.True(t, strings.HasPrefix(srv.cfg.DataDir, tmpfsRoot+"/"), "data dir %s is not on tmpfs", srv.cfg.DataDir)
+}
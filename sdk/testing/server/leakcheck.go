@@ -0,0 +1,40 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"go.uber.org/goleak"
+)
+
+// startupLeakIgnores are goroutines that outlive a fixture without having been
+// leaked by it.
+var startupLeakIgnores = []goleak.Option{
+	// idle keep-alive connections of the clients used during startup
+	goleak.IgnoreAnyFunction("net/http.(*persistConn).readLoop"),
+	goleak.IgnoreAnyFunction("net/http.(*persistConn).writeLoop"),
+	goleak.IgnoreTopFunction("k8s.io/klog/v2.(*flushDaemon).run.func1"),
+}
+
+// startupLeakCheck snapshots the running goroutines and returns a function that
+// reports the goroutines started since that are still running, apart from the
+// startupLeakIgnores.
+func startupLeakCheck() func() error {
+	opts := append([]goleak.Option{goleak.IgnoreCurrent()}, startupLeakIgnores...)
+	return func() error {
+		return goleak.Find(opts...)
+	}
+}
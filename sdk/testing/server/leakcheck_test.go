@@ -0,0 +1,37 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartupLeakCheck(t *testing.T) {
+	check := startupLeakCheck()
+	require.NoError(t, check())
+
+	stop := make(chan struct{})
+	go func() {
+		<-stop
+	}()
+	require.ErrorContains(t, check(), "found unexpected goroutines")
+
+	close(stop)
+	require.NoError(t, check())
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kcp-dev/kcp/sdk/testing/third_party/library-go/crypto"
+)
+
+// logicalClusterAdminGroup is the group kcp grants logical-cluster-admin
+// access to.
+const logicalClusterAdminGroup = "system:kcp:logical-cluster-admin"
+
+// writeLogicalClusterAdminKubeconfig generates a client CA and a client
+// certificate of a logical-cluster-admin below dataDir and writes a kubeconfig
+// using it to talk to kcp on the given port. It returns the path of the
+// kubeconfig and the arguments making kcp use the kubeconfig and trust the
+// client CA.
+func writeLogicalClusterAdminKubeconfig(dataDir, port string) (string, []string, error) {
+	dir := filepath.Join(dataDir, "logical-cluster-admin")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", nil, fmt.Errorf("could not create logical-cluster-admin dir: %w", err)
+	}
+	path := func(name string) string {
+		return filepath.Join(dir, name)
+	}
+
+	clientCA, err := crypto.MakeSelfSignedCA(path("client-ca.crt"), path("client-ca.key"), path("client-ca-serial.txt"), "kcp-logical-cluster-admin-client-ca", 365)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create logical-cluster-admin client CA: %w", err)
+	}
+	admin := &user.DefaultInfo{Name: "logical-cluster-admin", Groups: []string{logicalClusterAdminGroup}}
+	if _, err := clientCA.MakeClientCertificate(path("client.crt"), path("client.key"), admin, 365); err != nil {
+		return "", nil, fmt.Errorf("failed to create logical-cluster-admin client certificate: %w", err)
+	}
+
+	// The serving certificate is generated by kcp on startup, before the
+	// kubeconfig is loaded.
+	kubeconfig := clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"base": {
+				Server:               "https://localhost:" + port,
+				CertificateAuthority: filepath.Join(dataDir, "apiserver.crt"),
+			},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			"logical-cluster-admin": {
+				ClientCertificate: path("client.crt"),
+				ClientKey:         path("client.key"),
+			},
+		},
+		Contexts: map[string]*clientcmdapi.Context{
+			"base": {Cluster: "base", AuthInfo: "logical-cluster-admin"},
+		},
+		CurrentContext: "base",
+	}
+	kubeconfigPath := filepath.Join(dataDir, "logical-cluster-admin.kubeconfig")
+	if err := clientcmd.WriteToFile(kubeconfig, kubeconfigPath); err != nil {
+		return "", nil, fmt.Errorf("failed to write logical-cluster-admin kubeconfig: %w", err)
+	}
+
+	return kubeconfigPath, []string{
+		"--logical-cluster-admin-kubeconfig=" + kubeconfigPath,
+		"--client-ca-file=" + path("client-ca.crt"),
+	}, nil
+}
+
+// LogicalClusterAdminKubeconfig returns the kubeconfig kcp uses to connect to
+// shards as logical-cluster-admin. It is only available for servers started by
+// the fixture with WithLogicalClusterAdminKubeconfig.
+func LogicalClusterAdminKubeconfig(server RunningServer) (clientcmdapi.Config, error) {
+	s, ok := server.(*kcpServer)
+	if !ok || s.logicalClusterAdminKubeconfigPath == "" {
+		return clientcmdapi.Config{}, fmt.Errorf("server %s was not configured with WithLogicalClusterAdminKubeconfig", server.Name())
+	}
+	kubeconfig, err := clientcmd.LoadFromFile(s.logicalClusterAdminKubeconfigPath)
+	if err != nil {
+		return clientcmdapi.Config{}, err
+	}
+	return *kubeconfig, nil
+}
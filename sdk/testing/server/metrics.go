@@ -17,6 +17,7 @@ limitations under the License.
 package server
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -25,10 +26,14 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	gopkgyaml "gopkg.in/yaml.v3"
 
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -38,28 +43,76 @@ import (
 	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
 )
 
-func gatherMetrics(ctx context.Context, t TestingT, server RunningServer, directory string) {
-	cfg := server.RootShardSystemMasterBaseConfig(t)
+// gatherMetrics writes the metrics of the server or shard called name, served
+// at cfg, to directory.
+func gatherMetrics(ctx context.Context, t TestingT, name string, cfg *rest.Config, directory string, openMetrics bool) {
 	client, err := kcpclientset.NewForConfig(cfg)
 	if err != nil {
 		// Don't fail the test if we couldn't scrape metrics
-		t.Logf("error creating metrics client for server %s: %v", server.Name(), err)
+		t.Logf("error creating metrics client for server %s: %v", name, err)
 	}
 
-	raw, err := client.RESTClient().Get().RequestURI("/metrics").DoRaw(ctx)
+	req := client.RESTClient().Get().RequestURI("/metrics")
+	if openMetrics {
+		// protobuf is the only exposition format that carries exemplars and
+		// that we can parse, fall back to text if the server does not offer it
+		req = req.SetHeader("Accept", openMetricsScrapeAccept)
+	}
+	var contentType string
+	raw, err := req.Do(ctx).ContentType(&contentType).Raw()
 	if err != nil {
 		// Don't fail the test if we couldn't scrape metrics
-		t.Logf("error getting metrics for server %s: %v", server.Name(), err)
+		t.Logf("error getting metrics for server %s: %v", name, err)
 		return
 	}
 
-	metricsFile := filepath.Join(directory, fmt.Sprintf("%s-metrics.txt", server.Name()))
+	metricsFile := filepath.Join(directory, fmt.Sprintf("%s-metrics.txt", name))
+	if openMetrics {
+		var buf bytes.Buffer
+		if err := writeOpenMetrics(&buf, bytes.NewReader(raw), expfmt.ResponseFormat(http.Header{"Content-Type": []string{contentType}})); err != nil {
+			// Don't fail the test if we couldn't scrape metrics
+			t.Logf("error converting metrics of server %s to OpenMetrics: %v", name, err)
+			return
+		}
+		raw = buf.Bytes()
+		metricsFile = filepath.Join(directory, fmt.Sprintf("%s-metrics.openmetrics.txt", name))
+	}
 	if err := os.WriteFile(metricsFile, raw, 0o644); err != nil {
 		// Don't fail the test if we couldn't scrape metrics
 		t.Logf("error writing metrics file %s: %v", metricsFile, err)
 	}
 }
 
+var openMetricsScrapeAccept = string(expfmt.NewFormat(expfmt.TypeProtoDelim)) + ";q=0.7," + string(expfmt.NewFormat(expfmt.TypeTextPlain)) + ";q=0.3"
+
+// writeOpenMetrics decodes metric families in the given format from r and
+// writes them to w in the OpenMetrics 1.0.0 text format, terminated by # EOF.
+// Counters without the _total suffix get it appended, they would be exposed as
+// unknown otherwise. Exemplars are kept if the input format carries them.
+func writeOpenMetrics(w io.Writer, r io.Reader, format expfmt.Format) error {
+	dec := expfmt.NewDecoder(r, format)
+	enc := expfmt.NewEncoder(w, expfmt.NewFormat(expfmt.TypeOpenMetrics))
+	for {
+		mf := &dto.MetricFamily{}
+		if err := dec.Decode(mf); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		if mf.GetType() == dto.MetricType_COUNTER && !strings.HasSuffix(mf.GetName(), "_total") {
+			mf.Name = proto.String(mf.GetName() + "_total")
+		}
+		if err := enc.Encode(mf); err != nil {
+			return err
+		}
+	}
+	if closer, ok := enc.(expfmt.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
 func scrapeMetricsForServer(t TestingT, srv RunningServer) {
 	promUrl, set := os.LookupEnv("PROMETHEUS_URL")
 	if !set || promUrl == "" {
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	openMetricsSample   = regexp.MustCompile(`^([a-zA-Z_:][a-zA-Z0-9_:]*)(\{[^}]*\})? \S+( \S+)?( # \{[^}]*\} \S+( \S+)?)?$`)
+	openMetricsMetadata = regexp.MustCompile(`^# (TYPE|HELP|UNIT) ([a-zA-Z_:][a-zA-Z0-9_:]*)( .*)?$`)
+)
+
+// checkOpenMetrics does the structural checks of promtool check metrics on
+// OpenMetrics text: every line is metadata or a sample, samples of counters
+// end in _total and the exposition ends with # EOF. It returns the sample lines
+// by metric family.
+func checkOpenMetrics(t *testing.T, text string) map[string][]string {
+	t.Helper()
+
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	require.Equal(t, "# EOF", lines[len(lines)-1], "exposition must end with # EOF")
+
+	types := map[string]string{}
+	samples := map[string][]string{}
+	family := ""
+	for _, line := range lines[:len(lines)-1] {
+		if m := openMetricsMetadata.FindStringSubmatch(line); m != nil {
+			family = m[2]
+			if m[1] == "TYPE" {
+				types[family] = strings.TrimSpace(m[3])
+			}
+			continue
+		}
+		m := openMetricsSample.FindStringSubmatch(line)
+		require.NotNil(t, m, "invalid OpenMetrics line %q", line)
+		require.True(t, strings.HasPrefix(m[1], family), "sample %q outside of its metric family %q", line, family)
+		if types[family] == "counter" {
+			require.Contains(t, []string{family + "_total", family + "_created"}, m[1], "counter sample %q without _total suffix", line)
+		}
+		samples[family] = append(samples[family], line)
+	}
+	return samples
+}
+
+func TestWriteOpenMetrics(t *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "requests_total", Help: "Requests."}, []string{"code"})
+	legacy := prometheus.NewCounter(prometheus.CounterOpts{Name: "legacy_count", Help: "Counter without suffix."})
+	inflight := prometheus.NewGauge(prometheus.GaugeOpts{Name: "inflight", Help: "In-flight requests."})
+	registry.MustRegister(requests, legacy, inflight)
+	requests.WithLabelValues("200").(prometheus.ExemplarAdder).AddWithExemplar(3, prometheus.Labels{"trace_id": "abc"})
+	legacy.Add(2)
+	inflight.Set(1)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	for name, format := range map[string]expfmt.Format{
+		"protobuf": expfmt.NewFormat(expfmt.TypeProtoDelim),
+		"text":     expfmt.NewFormat(expfmt.TypeTextPlain),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var in bytes.Buffer
+			enc := expfmt.NewEncoder(&in, format)
+			for _, mf := range families {
+				require.NoError(t, enc.Encode(mf))
+			}
+
+			var out bytes.Buffer
+			require.NoError(t, writeOpenMetrics(&out, &in, format))
+			samples := checkOpenMetrics(t, out.String())
+
+			require.Len(t, samples["requests"], 1)
+			require.Len(t, samples["legacy_count"], 1)
+			require.Equal(t, "legacy_count_total 2.0", samples["legacy_count"][0])
+			require.Equal(t, []string{"inflight 1.0"}, samples["inflight"])
+			if format.FormatType() == expfmt.TypeProtoDelim {
+				require.Regexp(t, `^requests_total\{code="200"\} 3\.0 # \{trace_id="abc"\} 3\.0 \S+$`, samples["requests"][0], "exemplar must be preserved")
+			} else {
+				require.Equal(t, `requests_total{code="200"} 3.0`, samples["requests"][0])
+			}
+		})
+	}
+}
@@ -23,12 +23,72 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 )
 
-// GetFreePort asks the kernel for a free open port that is ready to use.
+// allocatedPorts are the ports handed out by GetFreePort and reservePorts in
+// this process that are still in use by a test. The lockfiles coordinate with
+// other processes, this set guards against handing out a port twice within the
+// process, e.g. after a lockfile was removed from under us.
+var allocatedPorts = struct {
+	sync.Mutex
+	ports map[int]struct{}
+}{ports: map[int]struct{}{}}
+
+// allocatePort records port as in use by this process and returns false if it
+// already was.
+func allocatePort(port int) bool {
+	allocatedPorts.Lock()
+	defer allocatedPorts.Unlock()
+	if _, found := allocatedPorts.ports[port]; found {
+		return false
+	}
+	allocatedPorts.ports[port] = struct{}{}
+	return true
+}
+
+func releasePort(port int) {
+	allocatedPorts.Lock()
+	defer allocatedPorts.Unlock()
+	delete(allocatedPorts.ports, port)
+}
+
+// portLockDir returns the directory of the port lockfiles shared by all test
+// processes.
+func portLockDir() (string, error) {
+	lockDir := filepath.Join(os.TempDir(), "kcp-e2e-ports")
+	if err := os.MkdirAll(lockDir, 0755); err != nil {
+		return "", fmt.Errorf("could not create port lockfile dir: %w", err)
+	}
+	return lockDir, nil
+}
+
+// lockPort atomically creates the lockfile of port and returns false if it
+// already exists.
+func lockPort(lockDir string, port int) (bool, error) {
+	f, err := os.OpenFile(filepath.Join(lockDir, strconv.Itoa(port)), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if os.IsExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("could not record port lockfile: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return false, fmt.Errorf("could not close port lockfile: %w", err)
+	}
+	return true, nil
+}
+
+// GetFreePort asks the kernel for a free open port that is ready to use. The
+// port is not handed out again, by this or other test processes using the
+// same temporary directory, until the test ends.
 func GetFreePort(t TestingT) (string, error) {
 	t.Helper()
 
+	lockDir, err := portLockDir()
+	if err != nil {
+		return "", err
+	}
+
 	for {
 		addr, err := net.ResolveTCPAddr("tcp", "localhost:0")
 		if err != nil {
@@ -45,7 +105,7 @@ func GetFreePort(t TestingT) (string, error) {
 				t.Errorf("could not close listener: %v", err)
 			}
 		}(l)
-		port := strconv.Itoa(l.Addr().(*net.TCPAddr).Port)
+		port := l.Addr().(*net.TCPAddr).Port
 		// Tests run in -parallel will run in separate processes, so we must use the file-system
 		// for sharing state and locking across them to coordinate who gets which port. Without
 		// some mechanism for sharing state, the following race is possible:
@@ -55,30 +115,74 @@ func GetFreePort(t TestingT) (string, error) {
 		// - process A attempts to use the port, fails as it is in use
 		// Therefore, holding the listener open is our kernel-based lock for this process, and while
 		// we hold it open we must record our intent to disk.
-		lockDir := filepath.Join(os.TempDir(), "kcp-e2e-ports")
-		if err := os.MkdirAll(lockDir, 0755); err != nil {
-			return "", fmt.Errorf("could not create port lockfile dir: %w", err)
+		if !allocatePort(port) {
+			t.Logf("found a port handed out before, retrying: %d", port)
+			continue
+		}
+		locked, err := lockPort(lockDir, port)
+		if err != nil {
+			releasePort(port)
+			return "", err
+		}
+		if !locked {
+			releasePort(port)
+			t.Logf("found a previously-seen port, retrying: %d", port)
+			continue
 		}
-		lockFile := filepath.Join(lockDir, port)
-		if _, err := os.Stat(lockFile); os.IsNotExist(err) {
-			// we've never seen this before, we can use it
-			f, err := os.Create(lockFile)
-			if err != nil {
-				return "", fmt.Errorf("could not record port lockfile: %w", err)
+		// the lifecycle of an accessory (and thereby its ports) is the test lifecycle
+		t.Cleanup(func() {
+			if err := os.Remove(filepath.Join(lockDir, strconv.Itoa(port))); err != nil {
+				t.Errorf("failed to remove port lockfile: %v", err)
 			}
-			if err := f.Close(); err != nil {
-				return "", fmt.Errorf("could not close port lockfile: %w", err)
+			releasePort(port)
+		})
+		return strconv.Itoa(port), nil
+	}
+}
+
+// reservePorts records the given ports in the same lockfiles as GetFreePort if
+// none of them is in use or recorded yet, and returns whether it did. The
+// lockfiles are removed when the test ends.
+func reservePorts(t TestingT, ports []int) (bool, error) {
+	t.Helper()
+
+	lockDir, err := portLockDir()
+	if err != nil {
+		return false, err
+	}
+
+	var reserved []int
+	release := func() {
+		for _, port := range reserved {
+			if err := os.Remove(filepath.Join(lockDir, strconv.Itoa(port))); err != nil {
+				t.Errorf("failed to remove port lockfile: %v", err)
 			}
-			// the lifecycle of an accessory (and thereby its ports) is the test lifecycle
-			t.Cleanup(func() {
-				if err := os.Remove(lockFile); err != nil {
-					t.Errorf("failed to remove port lockfile: %v", err)
-				}
-			})
-			return port, nil
-		} else if err != nil {
-			return "", fmt.Errorf("could not access port lockfile: %w", err)
+			releasePort(port)
+		}
+	}
+	for _, port := range ports {
+		l, err := net.Listen("tcp", net.JoinHostPort("localhost", strconv.Itoa(port)))
+		if err != nil {
+			release()
+			return false, nil //nolint:nilerr // the port is in use
+		}
+		if err := l.Close(); err != nil {
+			release()
+			return false, fmt.Errorf("could not close listener: %w", err)
+		}
+
+		if !allocatePort(port) {
+			release()
+			return false, nil
+		}
+		locked, err := lockPort(lockDir, port)
+		if err != nil || !locked {
+			releasePort(port)
+			release()
+			return false, err
 		}
-		t.Logf("found a previously-seen port, retrying: %s", port)
+		reserved = append(reserved, port)
 	}
+	t.Cleanup(release)
+	return true, nil
 }
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFreePortConcurrent(t *testing.T) {
+	// like 20 fixtures starting at the same time, each with the ports of
+	// newKcpServer
+	const fixtures, portsPerFixture = 20, 3
+
+	var lock sync.Mutex
+	seen := map[string]int{}
+	var wg sync.WaitGroup
+	for i := range fixtures {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range portsPerFixture {
+				port, err := GetFreePort(t)
+				if err != nil {
+					t.Errorf("failed to get free port: %v", err)
+					return
+				}
+				lock.Lock()
+				if other, found := seen[port]; found {
+					t.Errorf("port %s handed out to fixture %d and %d", port, other, i)
+				}
+				seen[port] = i
+				lock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	require.Len(t, seen, fixtures*portsPerFixture)
+}
+
+func TestReservePortsSkipsAllocatedPorts(t *testing.T) {
+	port, err := GetFreePort(t)
+	require.NoError(t, err)
+	p, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	reserved, err := reservePorts(t, []int{p})
+	require.NoError(t, err)
+	require.False(t, reserved, "port %d handed out by GetFreePort was reserved again", p)
+}
+
+func TestGetFreePortReleased(t *testing.T) {
+	var ports []int
+	t.Run("allocate", func(t *testing.T) {
+		for range 5 {
+			port, err := GetFreePort(t)
+			require.NoError(t, err)
+			p, err := strconv.Atoi(port)
+			require.NoError(t, err)
+			ports = append(ports, p)
+		}
+	})
+
+	lockDir, err := portLockDir()
+	require.NoError(t, err)
+	for _, p := range ports {
+		allocatedPorts.Lock()
+		_, found := allocatedPorts.ports[p]
+		allocatedPorts.Unlock()
+		require.False(t, found, "port %d is still allocated after the test ended", p)
+		_, err := os.Stat(filepath.Join(lockDir, strconv.Itoa(p)))
+		require.True(t, os.IsNotExist(err), "lockfile of port %d was not removed after the test ended", p)
+	}
+}
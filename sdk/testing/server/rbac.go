@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+)
+
+// bootstrapRBAC binds clusterRole to the user subject in the root workspace
+// and waits until the authorizer grants the first rule of the role to the
+// user.
+func bootstrapRBAC(ctx context.Context, cfg *rest.Config, subject, clusterRole string) error {
+	client, err := kcpkubernetesclientset.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	rbacClient := client.Cluster(core.RootCluster.Path()).RbacV1()
+
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "e2e:bootstrap:" + subject + ":" + clusterRole},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     clusterRole,
+		},
+		Subjects: []rbacv1.Subject{{
+			APIGroup: rbacv1.GroupName,
+			Kind:     rbacv1.UserKind,
+			Name:     subject,
+		}},
+	}
+	if _, err := rbacClient.ClusterRoleBindings().Create(ctx, binding, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to bind %s to %s: %w", clusterRole, subject, err)
+	}
+
+	role, err := rbacClient.ClusterRoles().Get(ctx, clusterRole, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get cluster role %s: %w", clusterRole, err)
+	}
+	if len(role.Rules) == 0 || len(role.Rules[0].Verbs) == 0 {
+		return nil
+	}
+	review := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{User: subject}}
+	if rule := role.Rules[0]; len(rule.NonResourceURLs) > 0 {
+		review.Spec.NonResourceAttributes = &authorizationv1.NonResourceAttributes{Path: rule.NonResourceURLs[0], Verb: rule.Verbs[0]}
+	} else {
+		review.Spec.ResourceAttributes = &authorizationv1.ResourceAttributes{Verb: rule.Verbs[0], Group: firstOrEmpty(rule.APIGroups), Resource: firstOrEmpty(rule.Resources)}
+	}
+
+	// The authorizer sees the binding through an informer, wait for it to
+	// have caught up so the subject can use its permissions right away.
+	return wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, wait.ForeverTestTimeout, true, func(ctx context.Context) (bool, error) {
+		resp, err := client.Cluster(core.RootCluster.Path()).AuthorizationV1().SubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			return false, nil //nolint:nilerr // retry until the timeout
+		}
+		return resp.Status.Allowed, nil
+	})
+}
+
+func firstOrEmpty(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -31,6 +31,11 @@ import (
 
 // WaitForReady waits for /livez and then /readyz to return success.
 func WaitForReady(ctx context.Context, cfg *rest.Config) error {
+	return waitForReady(ctx, cfg, time.Minute)
+}
+
+// waitForReady is WaitForReady, waiting at most timeout for each endpoint.
+func waitForReady(ctx context.Context, cfg *rest.Config, timeout time.Duration) error {
 	cfg = rest.CopyConfig(cfg)
 	if cfg.NegotiatedSerializer == nil {
 		cfg.NegotiatedSerializer = kubernetesscheme.Codecs.WithoutConversion()
@@ -41,19 +46,19 @@ func WaitForReady(ctx context.Context, cfg *rest.Config) error {
 		return fmt.Errorf("failed to create unversioned client: %w", err)
 	}
 
-	if err := waitForEndpoint(ctx, client, "/livez"); err != nil {
+	if err := waitForEndpoint(ctx, client, "/livez", timeout); err != nil {
 		return fmt.Errorf("server at %s didn't become ready: %w", cfg.Host, err)
 	}
-	if err := waitForEndpoint(ctx, client, "/readyz"); err != nil {
+	if err := waitForEndpoint(ctx, client, "/readyz", timeout); err != nil {
 		return fmt.Errorf("server at %s didn't become ready: %w", cfg.Host, err)
 	}
 
 	return nil
 }
 
-func waitForEndpoint(ctx context.Context, client *rest.RESTClient, endpoint string) error {
+func waitForEndpoint(ctx context.Context, client *rest.RESTClient, endpoint string, timeout time.Duration) error {
 	var lastError error
-	if err := wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, time.Minute, true, func(ctx context.Context) (bool, error) {
+	if err := wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, timeout, true, func(ctx context.Context) (bool, error) {
 		req := rest.NewRequest(client).RequestURI(endpoint)
 		if _, err := req.Do(ctx).Raw(); err != nil {
 			lastError = fmt.Errorf("error contacting %s: failed components: %v", req.URL(), unreadyComponentsFromError(err))
@@ -0,0 +1,394 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+)
+
+// shardedServer is a front-proxy with several kcp shards and a cache server
+// behind it, run by the sharded-test-server for the duration of a test. It
+// reuses the client side of externalKCPServer for its kubeconfigs.
+type shardedServer struct {
+	*externalKCPServer
+
+	workDir          string
+	readinessTimeout time.Duration
+	startupDuration  time.Duration
+	cancel           func()
+	shutdownComplete <-chan struct{}
+}
+
+// defaultShardedReadinessTimeout is how long the fixture waits for all shards
+// of a sharded server to become ready, unless WithReadinessTimeout is used.
+const defaultShardedReadinessTimeout = 5 * time.Minute
+
+// unsupportedShardedOptions returns the options set in cfg that the
+// sharded-test-server cannot honour.
+func unsupportedShardedOptions(cfg Config) []string {
+	var unsupported []string
+	for _, o := range []struct {
+		name string
+		set  bool
+	}{
+		{"WithRunInProcess", cfg.RunInProcess},
+		{"WithRootDirectoryOnTmpfs", cfg.DataDirOnTmpfs},
+		{"WithLogicalClusterAdminKubeconfig", cfg.LogicalClusterAdminKubeconfig},
+		{"WithEtcdTracing", cfg.EtcdTracing},
+		{"WithAccessLog", cfg.AccessLogPath != ""},
+		{"WithEtcdSizeSampling", cfg.EtcdSizeSamplingInterval > 0},
+		{"WithExternalVirtualWorkspaces", cfg.ExternalVirtualWorkspaces},
+		{"WithExternalURLScheme", cfg.ExternalURLScheme != ""},
+		{"WithExternalURLPathPrefix", cfg.ExternalURLPathPrefix != ""},
+	} {
+		if o.set {
+			unsupported = append(unsupported, o.name)
+		}
+	}
+	return unsupported
+}
+
+// ShardedTestServerPorts is the port layout of the sharded-test-server, with
+// all ports shifted by Offset. The sharded-test-server listens on these ports,
+// and the fixture reserves them before starting it.
+type ShardedTestServerPorts struct {
+	Offset int
+}
+
+// FrontProxy returns the port of the front-proxy.
+func (p ShardedTestServerPorts) FrontProxy() int {
+	return 6443 + p.Offset
+}
+
+// Shard returns the secure port of shard n, the root shard being 0.
+func (p ShardedTestServerPorts) Shard(n int) int {
+	return 6444 + n + p.Offset
+}
+
+// VirtualWorkspace returns the port of the virtual workspaces server of
+// shard n.
+func (p ShardedTestServerPorts) VirtualWorkspace(n int) int {
+	return 7444 + n + p.Offset
+}
+
+// EtcdClient returns the client port of the embedded etcd of shard n.
+func (p ShardedTestServerPorts) EtcdClient(n int) int {
+	return 2379 + 2*n + p.Offset
+}
+
+// EtcdPeer returns the peer port of the embedded etcd of shard n.
+func (p ShardedTestServerPorts) EtcdPeer(n int) int {
+	return 2380 + 2*n + p.Offset
+}
+
+// Cache returns the port of the cache server.
+func (p ShardedTestServerPorts) Cache() int {
+	return 8012 + p.Offset
+}
+
+// CacheEtcdClient returns the client port of the embedded etcd of the cache
+// server.
+func (p ShardedTestServerPorts) CacheEtcdClient() int {
+	return 8010 + p.Offset
+}
+
+// CacheEtcdPeer returns the peer port of the embedded etcd of the cache server.
+func (p ShardedTestServerPorts) CacheEtcdPeer() int {
+	return 8011 + p.Offset
+}
+
+// All returns all ports of a sharded-test-server with the given number of
+// shards.
+func (p ShardedTestServerPorts) All(shards int) []int {
+	ports := []int{p.FrontProxy(), p.CacheEtcdClient(), p.CacheEtcdPeer(), p.Cache()}
+	for n := range shards {
+		ports = append(ports, p.Shard(n), p.VirtualWorkspace(n), p.EtcdClient(n), p.EtcdPeer(n))
+	}
+	return ports
+}
+
+// ephemeralPortRangeStart is the start of the default ephemeral port range of
+// Linux. Ports of sharded servers stay below it, so that they do not collide
+// with client sockets.
+const ephemeralPortRangeStart = 32768
+
+// shardedPortOffsets returns the port offsets, in steps of 1000, that keep all
+// ports of a sharded-test-server with the given number of shards below the
+// ephemeral port range.
+func shardedPortOffsets(shards int) []int {
+	highest := slices.Max(ShardedTestServerPorts{}.All(shards))
+	var offsets []int
+	for offset := 1000; highest+offset < ephemeralPortRangeStart; offset += 1000 {
+		offsets = append(offsets, offset)
+	}
+	return offsets
+}
+
+// shardedServerArgs turns kcp arguments into sharded-test-server arguments
+// passed on to all shards, i.e. --foo=bar and --foo bar become --shard-foo=bar.
+// Arguments that are neither flags nor their values are rejected.
+func shardedServerArgs(args []string) ([]string, error) {
+	var ret []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") {
+			return nil, fmt.Errorf("unsupported argument %q, only --flag arguments can be passed to multiple shards", arg)
+		}
+		if !strings.Contains(arg, "=") && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			arg += "=" + args[i+1]
+			i++
+		}
+		ret = append(ret, "--shard-"+strings.TrimPrefix(arg, "--"))
+	}
+	return ret, nil
+}
+
+// startShardedServer starts cfg.Shards kcp shards with the sharded-test-server
+// and waits for them to be ready. The root shard is called "root", the others
+// "shard-1", "shard-2" and so on. cfg.Args and cfg.FeatureGates are passed to
+// all shards. Options the sharded-test-server does not support are rejected.
+func startShardedServer(t TestingT, cfg Config) (*shardedServer, error) {
+	t.Helper()
+
+	if unsupported := unsupportedShardedOptions(cfg); len(unsupported) > 0 {
+		return nil, fmt.Errorf("server %s: %s not supported with multiple shards", cfg.Name, strings.Join(unsupported, ", "))
+	}
+	featureGates, err := mergeFeatureGates(fmt.Sprintf("%s", utilfeature.DefaultFeatureGate), cfg.FeatureGates)
+	if err != nil {
+		return nil, fmt.Errorf("invalid feature gates: %w", err)
+	}
+	shardArgs, err := shardedServerArgs(cfg.Args)
+	if err != nil {
+		return nil, fmt.Errorf("server %s: %w", cfg.Name, err)
+	}
+	readinessTimeout := defaultShardedReadinessTimeout
+	if cfg.ReadinessTimeout > 0 {
+		readinessTimeout = cfg.ReadinessTimeout
+	}
+
+	start := time.Now()
+	var offset int
+	offsets := shardedPortOffsets(cfg.Shards)
+	for _, k := range rand.Perm(len(offsets)) {
+		ok, err := reservePorts(t, ShardedTestServerPorts{Offset: offsets[k]}.All(cfg.Shards))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			offset = offsets[k]
+			break
+		}
+	}
+	if offset == 0 {
+		return nil, fmt.Errorf("server %s: no free ports for %d shards", cfg.Name, cfg.Shards)
+	}
+
+	workDir := filepath.Join(cfg.DataDir, "kcp", cfg.Name)
+	logDir := filepath.Join(cfg.ArtifactDir, "kcp", cfg.Name, cfg.ArtifactSubdir)
+	for _, dir := range []string{workDir, logDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("could not create directory: %w", err)
+		}
+	}
+
+	runCfg := cfg
+	runCfg.ArtifactDir = logDir
+	runCfg.Args = append([]string{
+		"--number-of-shards=" + strconv.Itoa(cfg.Shards),
+		"--work-dir-path=" + workDir,
+		"--log-dir-path=" + logDir,
+		"--port-offset=" + strconv.Itoa(offset),
+		"--shard-feature-gates=" + featureGates,
+	}, shardArgs...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	shutdownComplete, err := runExecutable(ctx, t, "sharded-test-server", "SHARDED", nil, runCfg)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	s := &shardedServer{
+		workDir:          workDir,
+		readinessTimeout: readinessTimeout,
+		cancel: func() {
+			cancel()
+			<-shutdownComplete
+		},
+		shutdownComplete: shutdownComplete,
+	}
+	t.Cleanup(s.Stop)
+
+	readyFile := filepath.Join(workDir, ".kcp", "ready-to-test")
+	t.Logf("Waiting for %d shards of server %s", cfg.Shards, cfg.Name)
+	if err := wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, readinessTimeout, true, func(ctx context.Context) (bool, error) {
+		if s.Stopped() {
+			return false, fmt.Errorf("sharded-test-server exited")
+		}
+		_, err := os.Stat(readyFile)
+		return err == nil, nil
+	}); err != nil {
+		return nil, fmt.Errorf("server %s did not become ready: %w", cfg.Name, err)
+	}
+
+	shardKubeconfigs := map[string]string{}
+	for n := range cfg.Shards {
+		name := corev1alpha1.RootShard
+		if n > 0 {
+			name = fmt.Sprintf("shard-%d", n)
+		}
+		shardKubeconfigs[name] = filepath.Join(workDir, fmt.Sprintf(".kcp-%d", n), "admin.kubeconfig")
+	}
+	srv, err := NewExternalKCPServer(cfg.Name, filepath.Join(workDir, ".kcp", "admin.kubeconfig"), shardKubeconfigs, filepath.Join(workDir, ".kcp"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfigs of server %s: %w", cfg.Name, err)
+	}
+	s.externalKCPServer = srv.(*externalKCPServer)
+
+	readyCtx, readyCancel := context.WithTimeout(ctx, readinessTimeout)
+	defer readyCancel()
+	if err := waitForReady(readyCtx, s.RootShardSystemMasterBaseConfig(t), readinessTimeout); err != nil {
+		return nil, fmt.Errorf("server %s is not ready: %w", cfg.Name, err)
+	}
+	for i, hook := range cfg.ReadinessHooks {
+		if err := hook(ctx, s.RootShardSystemMasterBaseConfig(t)); err != nil {
+			return nil, fmt.Errorf("readiness hook %d for server %s failed: %w", i, cfg.Name, err)
+		}
+	}
+	s.startupDuration = time.Since(start)
+	writeStartupDuration(t, cfg.Name, logDir, s.startupDuration)
+
+	// registered after Stop, so that it runs while the shards are still up
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), wait.ForeverTestTimeout)
+		defer cancel()
+
+		t.Logf("Gathering metrics for the shards of server %s", cfg.Name)
+		for _, shard := range s.ShardNames() {
+			gatherMetrics(ctx, t, cfg.Name+"-"+shard, s.ShardSystemMasterBaseConfig(t, shard), logDir, cfg.OpenMetricsArtifacts)
+		}
+	})
+
+	return s, nil
+}
+
+// StartupDuration returns how long the sharded server took from being started
+// until all shards were ready, including readiness hooks.
+func (s *shardedServer) StartupDuration() time.Duration {
+	return s.startupDuration
+}
+
+// Stop stops all shards and waits for the sharded-test-server to exit.
+func (s *shardedServer) Stop() {
+	s.cancel()
+}
+
+// Stopped returns whether the sharded-test-server has exited.
+func (s *shardedServer) Stopped() bool {
+	select {
+	case <-s.shutdownComplete:
+		return true
+	default:
+		return false
+	}
+}
+
+// Restart restarts all shards one after another and returns once they are
+// ready again. The front-proxy and the cache server keep running. The
+// arguments of the shards cannot be changed, so opts must be empty.
+func (s *shardedServer) Restart(t TestingT, opts ...RunOption) error {
+	t.Helper()
+
+	if len(opts) > 0 {
+		return fmt.Errorf("server %s: the configuration of multiple shards cannot be changed on restart", s.Name())
+	}
+	for _, shard := range s.ShardNames() {
+		if err := s.restartShard(t, shard); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestartShard restarts the named shard of server and returns once it is ready
+// again. A server with a single shard is restarted as a whole, see Restart.
+// External servers cannot be restarted.
+func RestartShard(t TestingT, server RunningServer, shard string) error {
+	t.Helper()
+
+	switch s := server.(type) {
+	case *shardedServer:
+		return s.restartShard(t, shard)
+	case *kcpServer:
+		if shard != corev1alpha1.RootShard {
+			return fmt.Errorf("server %s has no shard %q", s.Name(), shard)
+		}
+		return s.Restart(t)
+	default:
+		return fmt.Errorf("shards of server %s cannot be restarted", server.Name())
+	}
+}
+
+// restartShard asks the sharded-test-server to kill the named shard and start
+// it again, with the same data directory and ports, and waits until it is
+// ready.
+func (s *shardedServer) restartShard(t TestingT, shard string) error {
+	t.Helper()
+
+	n := 0
+	if shard != corev1alpha1.RootShard {
+		if _, err := fmt.Sscanf(shard, "shard-%d", &n); err != nil || n < 1 || n >= len(s.ShardNames()) {
+			return fmt.Errorf("server %s has no shard %q", s.Name(), shard)
+		}
+	}
+
+	// must be kept in sync with cmd/sharded-test-server
+	restartFile := filepath.Join(s.workDir, fmt.Sprintf(".kcp-%d", n), "restart-requested")
+	t.Logf("Restarting shard %s of server %s", shard, s.Name())
+	if err := os.WriteFile(restartFile, nil, 0644); err != nil {
+		return fmt.Errorf("failed to request restart of shard %s: %w", shard, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.readinessTimeout)
+	defer cancel()
+	if err := wait.PollUntilContextCancel(ctx, 100*time.Millisecond, true, func(ctx context.Context) (bool, error) {
+		if s.Stopped() {
+			return false, fmt.Errorf("sharded-test-server exited")
+		}
+		_, err := os.Stat(restartFile)
+		return os.IsNotExist(err), nil
+	}); err != nil {
+		return fmt.Errorf("shard %s of server %s did not restart: %w", shard, s.Name(), err)
+	}
+	if err := waitForReady(ctx, s.ShardSystemMasterBaseConfig(t, shard), s.readinessTimeout); err != nil {
+		return fmt.Errorf("shard %s of server %s is not ready after restart: %w", shard, s.Name(), err)
+	}
+	t.Logf("Shard %s of server %s is ready again", shard, s.Name())
+	return nil
+}
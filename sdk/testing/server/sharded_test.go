@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardedServerArgs(t *testing.T) {
+	args, err := shardedServerArgs([]string{
+		"--v=4",
+		"--audit-policy-file", "/tmp/policy.yaml",
+		"--server-timing",
+		"--feature-gates=WorkspaceMounts=true",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"--shard-v=4",
+		"--shard-audit-policy-file=/tmp/policy.yaml",
+		"--shard-server-timing",
+		"--shard-feature-gates=WorkspaceMounts=true",
+	}, args)
+
+	for _, args := range [][]string{
+		{"-v=4"},
+		{"start"},
+		{"--server-timing", "--v", "4", "extra"},
+	} {
+		_, err := shardedServerArgs(args)
+		require.Error(t, err, "args %v", args)
+	}
+}
+
+func TestShardedTestServerPorts(t *testing.T) {
+	ports := ShardedTestServerPorts{Offset: 1000}.All(2)
+	require.ElementsMatch(t, []int{
+		7443, 9010, 9011, 9012, // front-proxy and cache server
+		7444, 8444, 3379, 3380, // root shard
+		7445, 8445, 3381, 3382, // shard-1
+	}, ports)
+}
+
+func TestShardedPortOffsets(t *testing.T) {
+	for _, shards := range []int{2, 10, 50} {
+		offsets := shardedPortOffsets(shards)
+		require.NotEmpty(t, offsets, "no offsets for %d shards", shards)
+		for _, offset := range offsets {
+			require.Less(t, slices.Max(ShardedTestServerPorts{Offset: offset}.All(shards)), ephemeralPortRangeStart, "offset %d for %d shards", offset, shards)
+		}
+	}
+}
+
+func TestUnsupportedShardedOptions(t *testing.T) {
+	cfg := Config{Name: "main", Shards: 2}
+	WithReadinessTimeout(time.Minute)(&cfg)
+	WithFeatureGates(map[string]bool{"WorkspaceMounts": true})(&cfg)
+	WithArtifactSubdir("sharded")(&cfg)
+	WithOpenMetricsArtifacts()(&cfg)
+	require.Empty(t, unsupportedShardedOptions(cfg))
+
+	WithEtcdTracing()(&cfg)
+	WithAccessLog("access.log")(&cfg)
+	require.Equal(t, []string{"WithEtcdTracing", "WithAccessLog"}, unsupportedShardedOptions(cfg))
+
+	other := Config{Shards: 2}
+	WithRootDirectoryOnTmpfs()(&other)
+	WithExternalURLScheme("http")(&other)
+	WithExternalURLPathPrefix("/kcp")(&other)
+	require.Equal(t, []string{"WithRootDirectoryOnTmpfs", "WithExternalURLScheme", "WithExternalURLPathPrefix"}, unsupportedShardedOptions(other))
+
+	_, err := startShardedServer(t, cfg)
+	require.ErrorContains(t, err, "WithEtcdTracing, WithAccessLog not supported with multiple shards")
+}
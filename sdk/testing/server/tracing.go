@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	collectortracev1 "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// tracingConfig is the apiserver tracing configuration sampling every request
+// and exporting the spans to the OTLP endpoint %s.
+const tracingConfig = `apiVersion: apiserver.config.k8s.io/v1beta1
+kind: TracingConfiguration
+endpoint: %s
+samplingRatePerMillion: 1000000
+`
+
+// traceCollector is a minimal OTLP gRPC trace receiver writing every span it
+// receives as one JSON line to a file.
+type traceCollector struct {
+	collectortracev1.UnimplementedTraceServiceServer
+
+	lock sync.Mutex
+	out  *os.File
+}
+
+// startTraceCollector starts a trace collector writing to tracesPath and
+// returns the --tracing-config-file argument pointing kcp to it. The collector
+// is stopped when the test ends.
+func startTraceCollector(t TestingT, dataDir, tracesPath string) (string, error) {
+	out, err := os.Create(tracesPath)
+	if err != nil {
+		return "", fmt.Errorf("could not create traces file: %w", err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		out.Close()
+		return "", fmt.Errorf("could not listen for traces: %w", err)
+	}
+
+	configPath := filepath.Join(dataDir, "tracing-config.yaml")
+	if err := os.WriteFile(configPath, []byte(fmt.Sprintf(tracingConfig, listener.Addr().String())), 0644); err != nil {
+		listener.Close()
+		out.Close()
+		return "", fmt.Errorf("could not write tracing config: %w", err)
+	}
+
+	srv := grpc.NewServer()
+	collectortracev1.RegisterTraceServiceServer(srv, &traceCollector{out: out})
+	go func() {
+		if err := srv.Serve(listener); err != nil {
+			t.Logf("trace collector stopped: %v", err)
+		}
+	}()
+	t.Cleanup(func() {
+		srv.Stop()
+		out.Close()
+	})
+
+	return "--tracing-config-file=" + configPath, nil
+}
+
+func (c *traceCollector) Export(_ context.Context, req *collectortracev1.ExportTraceServiceRequest) (*collectortracev1.ExportTraceServiceResponse, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, resourceSpans := range req.GetResourceSpans() {
+		for _, scopeSpans := range resourceSpans.GetScopeSpans() {
+			for _, span := range scopeSpans.GetSpans() {
+				bs, err := protojson.Marshal(span)
+				if err != nil {
+					return nil, err
+				}
+				if _, err := c.out.Write(append(bs, '\n')); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return &collectortracev1.ExportTraceServiceResponse{}, nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	collectortracev1 "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestTraceCollector(t *testing.T) {
+	dir := t.TempDir()
+	tracesPath := filepath.Join(dir, "traces.jsonl")
+
+	arg, err := startTraceCollector(t, dir, tracesPath)
+	require.NoError(t, err)
+	configPath, ok := strings.CutPrefix(arg, "--tracing-config-file=")
+	require.True(t, ok, "unexpected argument %q", arg)
+	config, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	endpoint, ok := strings.CutPrefix(strings.Split(string(config), "\n")[2], "endpoint: ")
+	require.True(t, ok, "no endpoint in tracing config:\n%s", config)
+
+	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = collectortracev1.NewTraceServiceClient(conn).Export(context.Background(), &collectortracev1.ExportTraceServiceRequest{
+		ResourceSpans: []*tracev1.ResourceSpans{{
+			ScopeSpans: []*tracev1.ScopeSpans{{
+				Spans: []*tracev1.Span{{Name: "etcdserverpb.KV/Range"}, {Name: "Create"}},
+			}},
+		}},
+	})
+	require.NoError(t, err)
+
+	traces, err := os.ReadFile(tracesPath)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(traces)), "\n")
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], `"etcdserverpb.KV/Range"`)
+}
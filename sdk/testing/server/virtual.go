@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/kcp-dev/kcp/sdk/testing/third_party/library-go/crypto"
+)
+
+// virtualWorkspaces is a virtual-workspaces server running as a separate
+// process next to a kcp server, as in a split deployment.
+type virtualWorkspaces struct {
+	dir  string
+	port string
+}
+
+// newVirtualWorkspaces generates the serving and client certificates for a
+// virtual-workspaces server below dataDir and returns the arguments wiring kcp
+// to it.
+func newVirtualWorkspaces(t TestingT, dataDir string) (*virtualWorkspaces, []string, error) {
+	t.Helper()
+
+	port, err := GetFreePort(t)
+	if err != nil {
+		return nil, nil, err
+	}
+	v := &virtualWorkspaces{
+		dir:  filepath.Join(dataDir, "virtual-workspaces"),
+		port: port,
+	}
+	if err := os.MkdirAll(v.dir, 0755); err != nil {
+		return nil, nil, fmt.Errorf("could not create virtual-workspaces dir: %w", err)
+	}
+
+	servingCA, err := crypto.MakeSelfSignedCA(v.path("serving-ca.crt"), v.path("serving-ca.key"), v.path("serving-ca-serial.txt"), "kcp-virtual-workspaces-serving-ca", 365)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create serving CA: %w", err)
+	}
+	if _, err := servingCA.MakeAndWriteServerCert(v.path("apiserver.crt"), v.path("apiserver.key"), sets.New("localhost", "127.0.0.1"), 365); err != nil {
+		return nil, nil, fmt.Errorf("failed to create serving certificate: %w", err)
+	}
+
+	// kcp authenticates against the virtual-workspaces server with a client
+	// certificate, e.g. for the initializing workspaces virtual workspace.
+	clientCA, err := crypto.MakeSelfSignedCA(v.path("client-ca.crt"), v.path("client-ca.key"), v.path("client-ca-serial.txt"), "kcp-virtual-workspaces-client-ca", 365)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create client CA: %w", err)
+	}
+	shardUser := &user.DefaultInfo{Name: "kcp-shard", Groups: []string{user.SystemPrivilegedGroup}}
+	if _, err := clientCA.MakeClientCertificate(v.path("shard-client.crt"), v.path("shard-client.key"), shardUser, 365); err != nil {
+		return nil, nil, fmt.Errorf("failed to create shard client certificate: %w", err)
+	}
+
+	return v, []string{
+		"--run-virtual-workspaces=false",
+		"--shard-virtual-workspace-url=" + v.url(),
+		"--shard-virtual-workspace-ca-file=" + v.path("serving-ca.crt"),
+		"--shard-client-cert-file=" + v.path("shard-client.crt"),
+		"--shard-client-key-file=" + v.path("shard-client.key"),
+	}, nil
+}
+
+func (v *virtualWorkspaces) path(name string) string {
+	return filepath.Join(v.dir, name)
+}
+
+func (v *virtualWorkspaces) url() string {
+	return "https://localhost:" + v.port
+}
+
+// start runs the virtual-workspaces server against the given ready kcp
+// server and waits up to the server's readiness timeout for it to become
+// ready. The returned channel is closed when the process exited after ctx
+// is done.
+func (v *virtualWorkspaces) start(ctx context.Context, t TestingT, c *kcpServer) (<-chan struct{}, error) {
+	raw, err := c.RawConfig()
+	if err != nil {
+		return nil, err
+	}
+	raw.CurrentContext = "shard-base"
+	kubeconfigPath := v.path("shard.kubeconfig")
+	if err := clientcmd.WriteToFile(raw, kubeconfigPath); err != nil {
+		return nil, fmt.Errorf("failed to write virtual-workspaces kubeconfig: %w", err)
+	}
+
+	shardCfg := c.RootShardSystemMasterBaseConfig(t)
+	shardURL, err := url.Parse(shardCfg.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	shutdownComplete, err := runExecutable(ctx, t, "virtual-workspaces", "VW", nil, Config{
+		Name:         c.cfg.Name + "-virtual-workspaces",
+		ArtifactDir:  c.cfg.ArtifactDir,
+		LogToConsole: c.cfg.LogToConsole,
+		Args: []string{
+			"--kubeconfig=" + kubeconfigPath,
+			"--cache-kubeconfig=" + kubeconfigPath,
+			"--authentication-kubeconfig=" + kubeconfigPath,
+			"--authentication-skip-lookup",
+			"--shard-external-url=" + shardURL.Scheme + "://" + shardURL.Host,
+			"--client-ca-file=" + v.path("client-ca.crt"),
+			"--tls-cert-file=" + v.path("apiserver.crt"),
+			"--tls-private-key-file=" + v.path("apiserver.key"),
+			"--secure-port=" + v.port,
+			"--v=4",
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	readyCtx, cancel := context.WithTimeout(ctx, c.readinessTimeout)
+	defer cancel()
+	if err := waitForReady(readyCtx, v.config(shardCfg), c.readinessTimeout); err != nil {
+		return shutdownComplete, fmt.Errorf("virtual-workspaces server did not become ready: %w", err)
+	}
+
+	return shutdownComplete, nil
+}
+
+// config returns a copy of cfg pointing to the virtual-workspaces server.
+func (v *virtualWorkspaces) config(cfg *rest.Config) *rest.Config {
+	cfg = rest.CopyConfig(cfg)
+	cfg.Host = v.url()
+	cfg.TLSClientConfig.CAData = nil
+	cfg.TLSClientConfig.CAFile = v.path("serving-ca.crt")
+	return cfg
+}
+
+// VirtualWorkspacesConfig returns a system:masters rest.Config for the
+// virtual-workspaces server of a server started with
+// WithExternalVirtualWorkspaces. Virtual workspace URLs, e.g. of
+// APIExportEndpointSlices, are relative to its host.
+func VirtualWorkspacesConfig(t TestingT, server RunningServer) *rest.Config {
+	t.Helper()
+
+	s, ok := server.(*kcpServer)
+	if !ok || s.virtualWorkspaces == nil {
+		t.Fatalf("server %s does not run external virtual workspaces", server.Name())
+		return nil
+	}
+	return s.virtualWorkspaces.config(s.RootShardSystemMasterBaseConfig(t))
+}
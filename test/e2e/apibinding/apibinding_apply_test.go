@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	apisv1alpha2 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	apisv1alpha1apply "github.com/kcp-dev/kcp/sdk/client/applyconfiguration/apis/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// TestAPIBindingStatusApplyRoundTrip verifies that the generated apply
+// configuration of the APIBinding status round-trips through apply and
+// extract.
+func TestAPIBindingStatusApplyRoundTrip(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	providerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+	consumerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	exportName := "round-trip"
+	t.Logf("Creating APIExport %s|%s", providerPath, exportName)
+	_, err = kcpClusterClient.Cluster(providerPath).ApisV1alpha2().APIExports().Create(ctx, &apisv1alpha2.APIExport{
+		ObjectMeta: metav1.ObjectMeta{Name: exportName},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create APIExport")
+
+	t.Logf("Binding to it in %s", consumerPath)
+	apiBinding := &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: exportName},
+		Spec: apisv1alpha1.APIBindingSpec{
+			Reference: apisv1alpha1.BindingReference{
+				Export: &apisv1alpha1.ExportBindingReference{
+					Path: providerPath.String(),
+					Name: exportName,
+				},
+			},
+		},
+	}
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		_, err := kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Create(ctx, apiBinding, metav1.CreateOptions{})
+		return err == nil, fmt.Sprintf("failed to create APIBinding: %v", err)
+	}, wait.ForeverTestTimeout, time.Millisecond*100, "failed to create APIBinding")
+
+	bindings := kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings()
+	kcptestinghelpers.EventuallyCondition(t, func() (conditions.Getter, error) {
+		return bindings.Get(ctx, exportName, metav1.GetOptions{})
+	}, kcptestinghelpers.Is(apisv1alpha1.InitialBindingCompleted), "APIBinding did not complete the initial binding")
+	binding, err := bindings.Get(ctx, exportName, metav1.GetOptions{})
+	require.NoError(t, err, "failed to get APIBinding")
+
+	// co-own the status fields set by the controller by applying the same
+	// values, so that it does not fight with the test
+	const fieldManager = "e2e-round-trip"
+	applyStatus := func(ctx context.Context, config *apisv1alpha1apply.APIBindingApplyConfiguration) (*apisv1alpha1.APIBinding, error) {
+		return bindings.ApplyStatus(ctx, config, metav1.ApplyOptions{FieldManager: fieldManager})
+	}
+	status := apisv1alpha1apply.APIBindingStatus().
+		WithAPIExportClusterName(binding.Status.APIExportClusterName).
+		WithPhase(binding.Status.Phase)
+	t.Logf("Applying the status of %s with %s", exportName, fieldManager)
+	binding, err = applyStatus(ctx, apisv1alpha1apply.APIBinding(exportName).WithStatus(status))
+	require.NoError(t, err, "failed to apply APIBinding status")
+
+	binding = framework.AssertApplyRoundTrip(ctx, t, binding, fieldManager, apisv1alpha1apply.ExtractAPIBindingStatus, applyStatus)
+
+	t.Logf("Verifying that the extracted status equals the applied one")
+	extracted, err := apisv1alpha1apply.ExtractAPIBindingStatus(binding, fieldManager)
+	require.NoError(t, err, "failed to extract APIBinding status")
+	require.Equal(t, status, extracted.Status, "extracted APIBinding status differs from the applied one")
+	require.Equal(t, apisv1alpha1.APIBindingPhaseBound, *extracted.Status.Phase)
+	require.Equal(t, binding.Status.APIExportClusterName, *extracted.Status.APIExportClusterName)
+}
+
+// TestAPIBindingExtractReapply verifies that the generated Extract functions
+// of APIBindings only return the fields owned by the given field manager, and
+// that re-applying the extracted configuration is a no-op.
+func TestAPIBindingExtractReapply(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	providerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+	consumerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	exportName := "extract"
+	t.Logf("Creating APIExport %s|%s", providerPath, exportName)
+	_, err = kcpClusterClient.Cluster(providerPath).ApisV1alpha2().APIExports().Create(ctx, &apisv1alpha2.APIExport{
+		ObjectMeta: metav1.ObjectMeta{Name: exportName},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create APIExport")
+
+	const fieldManager = "e2e-extract"
+	bindings := kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings()
+	spec := apisv1alpha1apply.APIBindingSpec().
+		WithReference(apisv1alpha1apply.BindingReference().
+			WithExport(apisv1alpha1apply.ExportBindingReference().
+				WithPath(providerPath.String()).
+				WithName(exportName)))
+	t.Logf("Applying APIBinding %s in %s with %s", exportName, consumerPath, fieldManager)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		_, err := bindings.Apply(ctx, apisv1alpha1apply.APIBinding(exportName).WithSpec(spec), metav1.ApplyOptions{FieldManager: fieldManager})
+		return err == nil, fmt.Sprintf("failed to apply APIBinding: %v", err)
+	}, wait.ForeverTestTimeout, time.Millisecond*100, "failed to apply APIBinding")
+
+	t.Logf("Applying a label with another field manager")
+	_, err = bindings.Apply(ctx, apisv1alpha1apply.APIBinding(exportName).WithLabels(map[string]string{"e2e-extract": "other"}), metav1.ApplyOptions{FieldManager: "e2e-other"})
+	require.NoError(t, err, "failed to apply APIBinding label")
+
+	kcptestinghelpers.EventuallyCondition(t, func() (conditions.Getter, error) {
+		return bindings.Get(ctx, exportName, metav1.GetOptions{})
+	}, kcptestinghelpers.Is(apisv1alpha1.InitialBindingCompleted), "APIBinding did not complete the initial binding")
+	binding, err := bindings.Get(ctx, exportName, metav1.GetOptions{})
+	require.NoError(t, err, "failed to get APIBinding")
+	require.NotEmpty(t, binding.Status.Conditions, "expected the controller to have set conditions")
+
+	t.Logf("Verifying that only the fields of %s are extracted", fieldManager)
+	extracted, err := apisv1alpha1apply.ExtractAPIBinding(binding, fieldManager)
+	require.NoError(t, err, "failed to extract APIBinding")
+	require.Equal(t, spec, extracted.Spec, "extracted spec differs from the applied one")
+	require.Empty(t, extracted.Labels, "extracted labels owned by another field manager")
+	require.Nil(t, extracted.Status, "extracted status of the main resource")
+
+	extractedStatus, err := apisv1alpha1apply.ExtractAPIBindingStatus(binding, fieldManager)
+	require.NoError(t, err, "failed to extract APIBinding status")
+	require.Nil(t, extractedStatus.Status, "extracted status owned by the controller")
+	require.Nil(t, extractedStatus.Spec, "extracted spec for the status subresource")
+
+	t.Logf("Re-applying the extracted apply configuration")
+	reapplied, err := bindings.Apply(ctx, extracted, metav1.ApplyOptions{FieldManager: fieldManager})
+	require.NoError(t, err, "failed to re-apply APIBinding")
+	require.Equal(t, binding.Spec, reapplied.Spec, "re-applying the extracted apply configuration changed the spec")
+	require.Equal(t, binding.Labels, reapplied.Labels, "re-applying the extracted apply configuration changed the labels")
+	require.Equal(t, managedFieldsOf(binding, fieldManager), managedFieldsOf(reapplied, fieldManager), "re-applying the extracted apply configuration changed the managed fields of %s", fieldManager)
+}
+
+func managedFieldsOf(binding *apisv1alpha1.APIBinding, fieldManager string) []metav1.ManagedFieldsEntry {
+	var entries []metav1.ManagedFieldsEntry
+	for _, entry := range binding.ManagedFields {
+		if entry.Manager == fieldManager {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
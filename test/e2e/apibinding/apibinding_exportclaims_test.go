@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	apisv1alpha2 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// TestAPIBindingExportPermissionClaims verifies that the permission claims of
+// an APIExport are propagated to the status of its APIBindings, also when they
+// change.
+func TestAPIBindingExportPermissionClaims(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	providerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+	consumerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	claims := []apisv1alpha2.PermissionClaim{
+		{
+			GroupResource: apisv1alpha2.GroupResource{Resource: "configmaps"},
+			All:           true,
+		},
+	}
+	exportName := "export-claims"
+	bindings := framework.ShareAPIExport(ctx, t, kcpClusterClient, providerPath, []logicalcluster.Path{consumerPath}, exportName, apisv1alpha2.APIExportSpec{
+		PermissionClaims: claims,
+	})
+	require.Empty(t, bindings[consumerPath].Status.AppliedPermissionClaims, "expected no claims to be applied without accepting them")
+
+	t.Logf("Verifying that the binding lists the claims of the export")
+	framework.WaitForExportPermissionClaims(ctx, t, kcpClusterClient, consumerPath, exportName, claims)
+
+	t.Logf("Verifying that changed claims of the export are propagated")
+	claims = append(claims, apisv1alpha2.PermissionClaim{
+		GroupResource: apisv1alpha2.GroupResource{Resource: "secrets"},
+		ResourceSelector: []apisv1alpha2.ResourceSelector{
+			{Namespace: "default"},
+		},
+	})
+	framework.SetAPIExportPermissionClaims(ctx, t, kcpClusterClient, providerPath, exportName, claims)
+	binding := framework.WaitForExportPermissionClaims(ctx, t, kcpClusterClient, consumerPath, exportName, claims)
+	require.Empty(t, binding.Status.AppliedPermissionClaims, "expected no claims to be applied without accepting them")
+
+	t.Logf("Verifying that removed claims of the export are propagated")
+	framework.SetAPIExportPermissionClaims(ctx, t, kcpClusterClient, providerPath, exportName, nil)
+	framework.WaitForExportPermissionClaims(ctx, t, kcpClusterClient, consumerPath, exportName, nil)
+}
+
+// TestAPIBindingAcceptPermissionClaims verifies that accepted permission
+// claims are applied and give the provider access to the claimed resources in
+// the consumer workspace, and that rejected claims are not applied.
+func TestAPIBindingAcceptPermissionClaims(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	providerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+	consumerPath, consumerWorkspace := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+	consumerClusterName := logicalcluster.Name(consumerWorkspace.Spec.Cluster)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	cfg := server.BaseConfig(t)
+	kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kube cluster client for server")
+
+	exportName := "accept-claims"
+	framework.ShareAPIExport(ctx, t, kcpClusterClient, providerPath, []logicalcluster.Path{consumerPath}, exportName, apisv1alpha2.APIExportSpec{
+		PermissionClaims: []apisv1alpha2.PermissionClaim{
+			{
+				GroupResource: apisv1alpha2.GroupResource{Resource: "configmaps"},
+				All:           true,
+			},
+		},
+	})
+	claim := apisv1alpha1.PermissionClaim{
+		GroupResource: apisv1alpha1.GroupResource{Resource: "configmaps"},
+		All:           true,
+	}
+
+	t.Logf("Creating a ConfigMap in %s", consumerPath)
+	_, err = kubeClusterClient.Cluster(consumerPath).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "claims"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create namespace")
+	_, err = kubeClusterClient.Cluster(consumerPath).CoreV1().ConfigMaps("claims").Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "claimed"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create ConfigMap")
+
+	binding := framework.AcceptPermissionClaims(ctx, t, kcpClusterClient, consumerPath, exportName, []apisv1alpha1.PermissionClaim{claim})
+	require.Equal(t, []apisv1alpha1.PermissionClaim{claim}, binding.Status.AppliedPermissionClaims)
+
+	export, err := kcpClusterClient.Cluster(providerPath).ApisV1alpha2().APIExports().Get(ctx, exportName, metav1.GetOptions{})
+	require.NoError(t, err, "failed to get APIExport")
+	rawConfig, err := server.RawConfig()
+	require.NoError(t, err)
+
+	t.Logf("Verifying that the claimed ConfigMap is accessible through the APIExport virtual workspace")
+	//nolint:staticcheck // SA1019 VirtualWorkspaces is deprecated but not removed yet
+	for _, vw := range export.Status.VirtualWorkspaces {
+		vwClusterClient, err := kcpdynamic.NewForConfig(apiexportVWConfig(t, rawConfig, vw.URL))
+		require.NoError(t, err)
+
+		kcptestinghelpers.Eventually(t, func() (bool, string) {
+			_, err := vwClusterClient.Cluster(consumerClusterName.Path()).Resource(corev1.SchemeGroupVersion.WithResource("configmaps")).Namespace("claims").Get(ctx, "claimed", metav1.GetOptions{})
+			if err != nil {
+				return false, fmt.Sprintf("failed to get ConfigMap through %s: %v", vw.URL, err)
+			}
+			return true, ""
+		}, wait.ForeverTestTimeout, 100*time.Millisecond, "claimed ConfigMap is not accessible through %s", vw.URL)
+	}
+
+	binding = framework.RejectPermissionClaims(ctx, t, kcpClusterClient, consumerPath, exportName, []apisv1alpha1.PermissionClaim{claim})
+	require.Empty(t, binding.Status.AppliedPermissionClaims)
+}
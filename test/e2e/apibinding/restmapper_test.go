@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	apisv1alpha2 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestRESTMapperForBoundAPI(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	providerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+	consumerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	group := "restmapper.wildwest.dev"
+	resourceSchema := &apisv1alpha1.APIResourceSchema{
+		ObjectMeta: metav1.ObjectMeta{Name: "today.sheriffs." + group},
+		Spec: apisv1alpha1.APIResourceSchemaSpec{
+			Group: group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:   "sheriffs",
+				Singular: "sheriff",
+				Kind:     "Sheriff",
+				ListKind: "SheriffList",
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apisv1alpha1.APIResourceVersion{{
+				Name:    "v1",
+				Served:  true,
+				Storage: true,
+				Schema:  runtime.RawExtension{Raw: []byte(`{"type":"object","x-kubernetes-preserve-unknown-fields":true}`)},
+			}},
+		},
+	}
+	t.Logf("Creating APIResourceSchema %s|%s", providerPath, resourceSchema.Name)
+	_, err = kcpClusterClient.Cluster(providerPath).ApisV1alpha1().APIResourceSchemas().Create(ctx, resourceSchema, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create APIResourceSchema")
+
+	framework.ShareAPIExport(ctx, t, kcpClusterClient, providerPath, []logicalcluster.Path{consumerPath}, group, apisv1alpha2.APIExportSpec{
+		Resources: []apisv1alpha2.ResourceSchema{{
+			Name:    "sheriffs",
+			Group:   group,
+			Schema:  resourceSchema.Name,
+			Storage: apisv1alpha2.ResourceSchemaStorage{CRD: &apisv1alpha2.ResourceSchemaStorageCRD{}},
+		}},
+	})
+
+	mapper := framework.RESTMapperForCluster(ctx, t, server, consumerPath)
+
+	t.Logf("Resolving Sheriff via the RESTMapper of %s", consumerPath)
+	var mapping *meta.RESTMapping
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		mapping, err = mapper.RESTMapping(schema.GroupKind{Group: group, Kind: "Sheriff"}, "v1")
+		if err != nil {
+			// the bound resource might not have been discovered yet
+			mapper.Reset()
+			return false, fmt.Sprintf("failed to map Sheriff: %v", err)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "Sheriff could not be mapped in %s", consumerPath)
+
+	require.Equal(t, schema.GroupVersionResource{Group: group, Version: "v1", Resource: "sheriffs"}, mapping.Resource)
+	require.Equal(t, meta.RESTScopeNameNamespace, mapping.Scope.Name())
+
+	t.Logf("Verifying Sheriff does not map in provider workspace %s", providerPath)
+	_, err = framework.RESTMapperForCluster(ctx, t, server, providerPath).RESTMapping(schema.GroupKind{Group: group, Kind: "Sheriff"}, "v1")
+	require.True(t, meta.IsNoMatchError(err), "expected no match for Sheriff in %s, got: %v", providerPath, err)
+}
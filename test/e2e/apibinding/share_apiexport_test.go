@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	apisv1alpha2 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestShareAPIExport(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	providerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+	consumerPaths := []logicalcluster.Path{}
+	for range 2 {
+		consumerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+		consumerPaths = append(consumerPaths, consumerPath)
+	}
+
+	cfg := server.BaseConfig(t)
+	kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct dynamic cluster client for server")
+
+	group := "shared.wildwest.dev"
+	resourceSchema := &apisv1alpha1.APIResourceSchema{
+		ObjectMeta: metav1.ObjectMeta{Name: "today.sheriffs." + group},
+		Spec: apisv1alpha1.APIResourceSchemaSpec{
+			Group: group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:   "sheriffs",
+				Singular: "sheriff",
+				Kind:     "Sheriff",
+				ListKind: "SheriffList",
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apisv1alpha1.APIResourceVersion{{
+				Name:    "v1",
+				Served:  true,
+				Storage: true,
+				Schema:  runtime.RawExtension{Raw: []byte(`{"type":"object","x-kubernetes-preserve-unknown-fields":true}`)},
+			}},
+		},
+	}
+	t.Logf("Creating APIResourceSchema %s|%s", providerPath, resourceSchema.Name)
+	_, err = kcpClusterClient.Cluster(providerPath).ApisV1alpha1().APIResourceSchemas().Create(ctx, resourceSchema, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create APIResourceSchema")
+
+	bindings := framework.ShareAPIExport(ctx, t, kcpClusterClient, providerPath, consumerPaths, group, apisv1alpha2.APIExportSpec{
+		Resources: []apisv1alpha2.ResourceSchema{{
+			Name:    "sheriffs",
+			Group:   group,
+			Schema:  resourceSchema.Name,
+			Storage: apisv1alpha2.ResourceSchemaStorage{CRD: &apisv1alpha2.ResourceSchemaStorageCRD{}},
+		}},
+	})
+	require.Len(t, bindings, len(consumerPaths))
+
+	gvr := schema.GroupVersionResource{Group: group, Version: "v1", Resource: "sheriffs"}
+	for _, consumerPath := range consumerPaths {
+		require.Len(t, bindings[consumerPath].Status.BoundResources, 1, "unexpected bound resources in %s", consumerPath)
+
+		t.Logf("Creating and listing sheriffs in %s", consumerPath)
+		sheriff := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": gvr.GroupVersion().String(),
+			"kind":       "Sheriff",
+			"metadata":   map[string]interface{}{"name": "wyatt"},
+		}}
+		kcptestinghelpers.Eventually(t, func() (bool, string) {
+			_, err := dynamicClusterClient.Cluster(consumerPath).Resource(gvr).Namespace(metav1.NamespaceDefault).Create(ctx, sheriff, metav1.CreateOptions{})
+			if err != nil {
+				return false, fmt.Sprintf("failed to create sheriff: %v", err)
+			}
+			return true, ""
+		}, wait.ForeverTestTimeout, 100*time.Millisecond, "sheriff could not be created in %s", consumerPath)
+
+		list, err := dynamicClusterClient.Cluster(consumerPath).Resource(gvr).Namespace(metav1.NamespaceDefault).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err, "failed to list sheriffs in %s", consumerPath)
+		require.Len(t, list.Items, 1, "each consumer should only see its own sheriffs")
+	}
+}
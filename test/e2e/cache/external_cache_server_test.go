@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	cacheclient "github.com/kcp-dev/kcp/pkg/cache/client"
+	"github.com/kcp-dev/kcp/pkg/cache/client/shard"
+	apisv1alpha2 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+	cache2e "github.com/kcp-dev/kcp/test/e2e/reconciler/cache"
+)
+
+func TestExternalCacheServer(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	cacheServer := kcptestingserver.StartCacheServer(t)
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithCustomArguments("--cache-kubeconfig="+cacheServer.KubeconfigPath()))
+
+	wsPath, ws := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	kcpClusterClient, err := kcpclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+
+	t.Logf("Creating an APIExport in %s", wsPath)
+	_, err = kcpClusterClient.Cluster(wsPath).ApisV1alpha2().APIExports().Create(ctx, &apisv1alpha2.APIExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "cached"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	cacheDynamicClient, err := kcpdynamic.NewForConfig(cache2e.ClientRoundTrippersFor(cacheServer.BaseConfig(t)))
+	require.NoError(t, err)
+
+	t.Logf("Waiting for the APIExport to be readable from the external cache server")
+	clusterName := logicalcluster.Name(ws.Spec.Cluster)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		cached, err := cacheDynamicClient.Cluster(clusterName.Path()).Resource(apisv1alpha2.SchemeGroupVersion.WithResource("apiexports")).Get(cacheclient.WithShardInContext(ctx, shard.New("root")), "cached", metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Sprintf("failed to get APIExport from the cache server: %v", err)
+		}
+		return cached.GetName() == "cached", ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "APIExport was not replicated to the external cache server")
+}
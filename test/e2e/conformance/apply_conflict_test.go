@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestApplyConflict(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kube cluster client")
+	configMaps := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps(metav1.NamespaceDefault)
+
+	applyWith := func(manager, value string) framework.ApplyFunc {
+		return func(ctx context.Context, force bool) (metav1.Object, error) {
+			cm := corev1ac.ConfigMap("ssa", metav1.NamespaceDefault).WithData(map[string]string{"owner": value})
+			return configMaps.Apply(ctx, cm, metav1.ApplyOptions{FieldManager: manager, Force: force})
+		}
+	}
+
+	framework.AssertApplyConflict(ctx, t, applyWith("manager-a", "a"), applyWith("manager-b", "b"))
+
+	cm, err := configMaps.Get(ctx, "ssa", metav1.GetOptions{})
+	require.NoError(t, err, "failed to get configmap")
+	require.Equal(t, "b", cm.Data["owner"], "force apply did not change the value")
+	requireOnlyApplier(t, cm, "manager-b")
+}
+
+func requireOnlyApplier(t *testing.T, cm *corev1.ConfigMap, manager string) {
+	t.Helper()
+
+	var appliers []string
+	for _, entry := range cm.ManagedFields {
+		if entry.Operation == metav1.ManagedFieldsOperationApply {
+			appliers = append(appliers, entry.Manager)
+		}
+	}
+	require.Equal(t, []string{manager}, appliers, "unexpected apply managers")
+}
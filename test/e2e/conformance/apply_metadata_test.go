@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestApplyMetadataPreserved(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kube cluster client")
+	configMaps := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps(metav1.NamespaceDefault)
+	getFn := func(ctx context.Context) (metav1.Object, error) {
+		return configMaps.Get(ctx, "metadata", metav1.GetOptions{})
+	}
+	applyFn := func(labels, annotations map[string]string) framework.ApplyFunc {
+		return func(ctx context.Context, force bool) (metav1.Object, error) {
+			cm := corev1ac.ConfigMap("metadata", metav1.NamespaceDefault).WithLabels(labels).WithAnnotations(annotations)
+			return configMaps.Apply(ctx, cm, metav1.ApplyOptions{FieldManager: "e2e-applier", Force: force})
+		}
+	}
+
+	t.Logf("Applying a configmap with labels and annotations")
+	labels := map[string]string{"app": "e2e", "tier": "test"}
+	annotations := map[string]string{"example.com/note": "applied"}
+	framework.AssertMetadataPreserved(ctx, t, applyFn(labels, annotations), getFn, labels, annotations)
+
+	t.Logf("Re-applying without a label and annotation, expecting them to be removed")
+	labels = map[string]string{"app": "e2e"}
+	framework.AssertMetadataPreserved(ctx, t, applyFn(labels, nil), getFn, labels, nil)
+}
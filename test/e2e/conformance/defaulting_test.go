@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcpapiextensionsclientset "github.com/kcp-dev/client-go/apiextensions/client"
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestDefaulting(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	cfg := server.BaseConfig(t)
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kube cluster client")
+	crdClusterClient, err := kcpapiextensionsclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct apiextensions cluster client")
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct dynamic cluster client")
+
+	t.Run("built-in", func(t *testing.T) {
+		secrets := kubeClusterClient.Cluster(wsPath).CoreV1().Secrets("default")
+		framework.AssertDefaulted(ctx, t,
+			func(ctx context.Context) (runtime.Object, error) {
+				return secrets.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "defaulted"}}, metav1.CreateOptions{})
+			},
+			func(ctx context.Context) (runtime.Object, error) {
+				return secrets.Get(ctx, "defaulted", metav1.GetOptions{})
+			},
+			map[string]interface{}{"type": string(corev1.SecretTypeOpaque)},
+		)
+	})
+
+	t.Run("custom resource", func(t *testing.T) {
+		crd := &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "gadgets.defaulting.e2e.kcp.io"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group: "defaulting.e2e.kcp.io",
+				Scope: apiextensionsv1.ClusterScoped,
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Kind:     "Gadget",
+					ListKind: "GadgetList",
+					Singular: "gadget",
+					Plural:   "gadgets",
+				},
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+					Name:    "v1",
+					Served:  true,
+					Storage: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"spec": {
+									Type:    "object",
+									Default: &apiextensionsv1.JSON{Raw: []byte(`{}`)},
+									Properties: map[string]apiextensionsv1.JSONSchemaProps{
+										"replicas": {Type: "integer", Default: &apiextensionsv1.JSON{Raw: []byte(`1`)}},
+										"mode":     {Type: "string", Default: &apiextensionsv1.JSON{Raw: []byte(`"auto"`)}},
+									},
+								},
+							},
+						},
+					},
+				}},
+			},
+		}
+		t.Logf("Creating CRD %s", crd.Name)
+		_, err := crdClusterClient.Cluster(wsPath).ApiextensionsV1().CustomResourceDefinitions().Create(ctx, crd, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create CRD")
+
+		gvr := schema.GroupVersionResource{Group: crd.Spec.Group, Version: "v1", Resource: crd.Spec.Names.Plural}
+		gadgets := dynamicClusterClient.Cluster(wsPath).Resource(gvr)
+		kcptestinghelpers.Eventually(t, func() (bool, string) {
+			_, err := gadgets.List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return false, fmt.Sprintf("gadgets are not served yet: %v", err)
+			}
+			return true, ""
+		}, wait.ForeverTestTimeout, 100*time.Millisecond, "gadgets were not served")
+
+		gadget := framework.NewUnstructured(gvr.GroupVersion().WithKind(crd.Spec.Names.Kind), "defaulted", "", nil)
+		framework.AssertDefaulted(ctx, t,
+			func(ctx context.Context) (runtime.Object, error) {
+				return gadgets.Create(ctx, gadget, metav1.CreateOptions{})
+			},
+			func(ctx context.Context) (runtime.Object, error) {
+				return gadgets.Get(ctx, gadget.GetName(), metav1.GetOptions{})
+			},
+			map[string]interface{}{"spec.replicas": 1, "spec.mode": "auto"},
+		)
+	})
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestDeleteCollection(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	cfg := server.BaseConfig(t)
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kube cluster client")
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct dynamic cluster client")
+
+	doomed := map[string]string{"e2e.kcp.io/delete-collection": "doomed"}
+	kept := map[string]string{"e2e.kcp.io/delete-collection": "kept"}
+
+	t.Logf("Creating labeled configmaps in %q", wsPath)
+	for i := range 5 {
+		labels := doomed
+		if i%2 == 1 {
+			labels = kept
+		}
+		_, err := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("default").Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("cm-%d", i), Labels: labels},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create configmap %d", i)
+	}
+
+	framework.DeleteCollection(ctx, t, dynamicClusterClient, corev1.SchemeGroupVersion.WithResource("configmaps"), wsPath, "default", "e2e.kcp.io/delete-collection=doomed")
+
+	t.Logf("Verifying the configmaps not matching the selector are kept")
+	list, err := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{LabelSelector: "e2e.kcp.io/delete-collection=kept"})
+	require.NoError(t, err, "failed to list configmaps")
+	require.Len(t, list.Items, 2, "unexpected number of kept configmaps")
+
+	t.Logf("Creating labeled cluster roles in %q", wsPath)
+	for i := range 3 {
+		_, err := kubeClusterClient.Cluster(wsPath).RbacV1().ClusterRoles().Create(ctx, &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("delete-collection-%d", i), Labels: doomed},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create cluster role %d", i)
+	}
+
+	framework.DeleteCollection(ctx, t, dynamicClusterClient, rbacv1.SchemeGroupVersion.WithResource("clusterroles"), wsPath, "", "e2e.kcp.io/delete-collection=doomed")
+}
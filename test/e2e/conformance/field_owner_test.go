@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestFieldOwner(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kube cluster client")
+	configMaps := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps(metav1.NamespaceDefault)
+
+	t.Logf("Applying a configmap with manager e2e-applier")
+	_, err = configMaps.Apply(ctx, corev1ac.ConfigMap("owned", metav1.NamespaceDefault).WithData(map[string]string{"applied": "true"}), metav1.ApplyOptions{FieldManager: "e2e-applier"})
+	require.NoError(t, err, "failed to apply configmap")
+
+	t.Logf("Updating another field with manager e2e-updater")
+	cm, err := configMaps.Get(ctx, "owned", metav1.GetOptions{})
+	require.NoError(t, err, "failed to get configmap")
+	cm.Data["updated"] = "true"
+	_, err = configMaps.Update(ctx, cm, metav1.UpdateOptions{FieldManager: "e2e-updater"})
+	require.NoError(t, err, "failed to update configmap")
+
+	entries := framework.ManagedFields(ctx, t, func(ctx context.Context) (metav1.Object, error) {
+		return configMaps.Get(ctx, "owned", metav1.GetOptions{})
+	})
+	require.Len(t, entries, 2, "expected one managed fields entry per manager")
+
+	cm, err = configMaps.Get(ctx, "owned", metav1.GetOptions{})
+	require.NoError(t, err, "failed to get configmap")
+	framework.AssertFieldOwner(t, cm, "data.applied", "e2e-applier")
+	framework.AssertFieldOwner(t, cm, "data.updated", "e2e-updater")
+}
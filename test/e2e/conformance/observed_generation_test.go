@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	kcpapiextensionsclientset "github.com/kcp-dev/client-go/apiextensions/client"
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	"github.com/kcp-dev/kcp/test/e2e/fixtures/kube"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestWaitForObservedGeneration(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	cfg := server.BaseConfig(t)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+
+	crdClusterClient, err := kcpapiextensionsclientset.NewForConfig(cfg)
+	require.NoError(t, err, "error creating crd cluster client")
+	kube.Create(t, crdClusterClient.ApiextensionsV1().CustomResourceDefinitions().Cluster(orgPath), metav1.GroupResource{Group: "apps.k8s.io", Resource: "deployments"})
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+	require.NoError(t, err, "error creating kube cluster client")
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "error creating dynamic cluster client")
+	deployments := kubeClusterClient.Cluster(orgPath).AppsV1().Deployments("default")
+
+	t.Logf("Creating deployment")
+	d, err := deployments.Create(ctx, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "observed"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "observed"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "observed"}},
+			},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "error creating deployment")
+
+	// There is no deployment controller in kcp, so act as one: record the
+	// generation in status with some delay after every spec change.
+	observe := func(generation int64) {
+		go func() {
+			time.Sleep(time.Second)
+			d, err := deployments.Get(ctx, "observed", metav1.GetOptions{})
+			if err != nil {
+				t.Errorf("failed to get deployment: %v", err)
+				return
+			}
+			d.Status.ObservedGeneration = generation
+			if _, err := deployments.UpdateStatus(ctx, d, metav1.UpdateOptions{}); err != nil {
+				t.Errorf("failed to update deployment status: %v", err)
+			}
+		}()
+	}
+
+	observe(d.Generation)
+	t.Logf("Waiting for the initial generation %d to be observed", d.Generation)
+	d = framework.WaitForObservedGeneration(ctx, t, func(ctx context.Context) (*appsv1.Deployment, error) {
+		return deployments.Get(ctx, "observed", metav1.GetOptions{})
+	})
+
+	t.Logf("Updating deployment spec")
+	d.Spec.Replicas = ptr.To[int32](2)
+	d, err = deployments.Update(ctx, d, metav1.UpdateOptions{})
+	require.NoError(t, err, "error updating deployment")
+	require.Greater(t, d.Generation, d.Status.ObservedGeneration, "spec update should bump the generation")
+
+	observe(d.Generation)
+	t.Logf("Waiting for generation %d to be observed through the dynamic client", d.Generation)
+	u := framework.WaitForObservedGeneration(ctx, t, func(ctx context.Context) (*unstructured.Unstructured, error) {
+		return dynamicClusterClient.Cluster(orgPath).Resource(appsv1.SchemeGroupVersion.WithResource("deployments")).Namespace("default").Get(ctx, "observed", metav1.GetOptions{})
+	})
+	require.Equal(t, d.Generation, u.GetGeneration())
+}
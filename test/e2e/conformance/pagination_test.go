@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestPaginatedList(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	cfg := server.BaseConfig(t)
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kube cluster client")
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct dynamic cluster client")
+
+	const count = 57
+	t.Logf("Creating %d configmaps in %q", count, wsPath)
+	for _, ns := range []string{"pagination-a", "pagination-b"} {
+		_, err := kubeClusterClient.Cluster(wsPath).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}}, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create namespace %s", ns)
+	}
+	for i := range count {
+		ns := "pagination-a"
+		if i%2 == 1 {
+			ns = "pagination-b"
+		}
+		_, err := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps(ns).Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("cm-%02d", i)},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create configmap %d", i)
+	}
+
+	t.Logf("Listing the workspace configmaps in pages of 10")
+	items, pages := framework.ListAllPages(ctx, t, dynamicClusterClient.Cluster(wsPath), corev1.SchemeGroupVersion.WithResource("configmaps"), 10)
+
+	// Namespaces can contain other configmaps, e.g. kube-root-ca.crt.
+	var ours int
+	for _, item := range items {
+		if strings.HasPrefix(item.GetName(), "cm-") {
+			ours++
+		}
+	}
+	require.Equal(t, count, ours, "unexpected number of configmaps")
+	require.GreaterOrEqual(t, pages, count/10+1, "expected the list to be split into pages")
+}
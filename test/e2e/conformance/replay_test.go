@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestReplayOperations(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	recordPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+	replayPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct dynamic cluster client")
+
+	gvr := corev1.SchemeGroupVersion.WithResource("configmaps")
+	gvk := corev1.SchemeGroupVersion.WithKind("ConfigMap")
+
+	t.Logf("Recording operations in %s", recordPath)
+	recorder := framework.NewOperationRecorder(dynamicClusterClient.Cluster(recordPath))
+	configMaps := recorder.Resource(gvr).Namespace(metav1.NamespaceDefault)
+
+	created, err := configMaps.Create(ctx, framework.NewUnstructured(gvk, "replayed", metav1.NamespaceDefault, nil), metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = configMaps.Create(ctx, framework.NewUnstructured(gvk, "replayed", metav1.NamespaceDefault, nil), metav1.CreateOptions{})
+	require.True(t, apierrors.IsAlreadyExists(err), "expected AlreadyExists, got %v", err)
+	require.NoError(t, unstructured.SetNestedStringMap(created.Object, map[string]string{"step": "updated"}, "data"))
+	_, err = configMaps.Update(ctx, created, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	_, err = configMaps.Patch(ctx, "replayed", types.MergePatchType, []byte(`{"metadata":{"labels":{"replayed":"true"}}}`), metav1.PatchOptions{})
+	require.NoError(t, err)
+	_, err = configMaps.Create(ctx, framework.NewUnstructured(gvk, "deleted", metav1.NamespaceDefault, nil), metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, configMaps.Delete(ctx, "deleted", metav1.DeleteOptions{}))
+	err = configMaps.Delete(ctx, "deleted", metav1.DeleteOptions{})
+	require.True(t, apierrors.IsNotFound(err), "expected NotFound, got %v", err)
+
+	require.Len(t, recorder.Operations(), 7)
+	opsFile := filepath.Join(t.TempDir(), "operations.json")
+	recorder.Save(t, opsFile)
+
+	t.Logf("Replaying the operations in %s", replayPath)
+	replayClient := dynamicClusterClient.Cluster(replayPath)
+	framework.ReplayOperations(ctx, t, replayClient, opsFile)
+
+	replayed, err := replayClient.Resource(gvr).Namespace(metav1.NamespaceDefault).Get(ctx, "replayed", metav1.GetOptions{})
+	require.NoError(t, err)
+	data, _, err := unstructured.NestedStringMap(replayed.Object, "data")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"step": "updated"}, data)
+	require.Equal(t, "true", replayed.GetLabels()["replayed"])
+	_, err = replayClient.Resource(gvr).Namespace(metav1.NamespaceDefault).Get(ctx, "deleted", metav1.GetOptions{})
+	require.True(t, apierrors.IsNotFound(err), "expected deleted configmap to be gone, got %v", err)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcpapiextensionsclientset "github.com/kcp-dev/client-go/apiextensions/client"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	"github.com/kcp-dev/kcp/test/e2e/fixtures/wildwest"
+	wildwestv1alpha1 "github.com/kcp-dev/kcp/test/e2e/fixtures/wildwest/apis/wildwest/v1alpha1"
+	wildwestv1alpha1ac "github.com/kcp-dev/kcp/test/e2e/fixtures/wildwest/client/applyconfiguration/wildwest/v1alpha1"
+	wildwestclientset "github.com/kcp-dev/kcp/test/e2e/fixtures/wildwest/client/clientset/versioned/cluster"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestStatusUpdateKeepsSpec(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	cfg := server.BaseConfig(t)
+	crdClusterClient, err := kcpapiextensionsclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct apiextensions cluster client")
+	wildwestClusterClient, err := wildwestclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct wildwest cluster client")
+
+	t.Logf("Installing the cowboys CRD in %q", wsPath)
+	wildwest.Create(t, wsPath, crdClusterClient.ApiextensionsV1().CustomResourceDefinitions(), metav1.GroupResource{Group: wildwestv1alpha1.SchemeGroupVersion.Group, Resource: "cowboys"})
+
+	cowboys := wildwestClusterClient.Cluster(wsPath).WildwestV1alpha1().Cowboys(metav1.NamespaceDefault)
+	spec := wildwestv1alpha1.CowboySpec{Intent: "ride into the sunset"}
+	t.Logf("Creating cowboy with intent %q", spec.Intent)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		_, err := cowboys.Create(ctx, &wildwestv1alpha1.Cowboy{
+			ObjectMeta: metav1.ObjectMeta{Name: "lucky-luke"},
+			Spec:       spec,
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return false, fmt.Sprintf("failed to create cowboy: %v", err)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "cowboy could not be created")
+
+	getFn := func(ctx context.Context) (runtime.Object, error) {
+		return cowboys.Get(ctx, "lucky-luke", metav1.GetOptions{})
+	}
+
+	t.Logf("Applying the status of the cowboy")
+	framework.AssertStatusOnly(ctx, t, func(ctx context.Context) (runtime.Object, error) {
+		return cowboys.ApplyStatus(ctx, wildwestv1alpha1ac.Cowboy("lucky-luke", metav1.NamespaceDefault).
+			WithStatus(wildwestv1alpha1ac.CowboyStatus().WithResult("arrived")),
+			metav1.ApplyOptions{FieldManager: "e2e-test-runner", Force: true})
+	}, getFn, spec)
+
+	t.Logf("Updating the status of the cowboy with a modified spec")
+	framework.AssertStatusOnly(ctx, t, func(ctx context.Context) (runtime.Object, error) {
+		cowboy, err := cowboys.Get(ctx, "lucky-luke", metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		cowboy.Spec.Intent = "written through the status endpoint"
+		cowboy.Status.Result = "updated"
+		return cowboys.UpdateStatus(ctx, cowboy, metav1.UpdateOptions{})
+	}, getFn, spec)
+}
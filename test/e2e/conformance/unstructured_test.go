@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcpapiextensionsclientset "github.com/kcp-dev/client-go/apiextensions/client"
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	"github.com/kcp-dev/kcp/test/e2e/fixtures/wildwest"
+	wildwestv1alpha1 "github.com/kcp-dev/kcp/test/e2e/fixtures/wildwest/apis/wildwest/v1alpha1"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestApplyUnstructured(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	cfg := server.BaseConfig(t)
+	crdClusterClient, err := kcpapiextensionsclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct apiextensions cluster client")
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct dynamic cluster client")
+
+	t.Logf("Installing the sheriffs CRD in %q", wsPath)
+	wildwest.Create(t, wsPath, crdClusterClient.ApiextensionsV1().CustomResourceDefinitions(), metav1.GroupResource{Group: wildwestv1alpha1.SchemeGroupVersion.Group, Resource: "sheriffs"})
+
+	sheriff := framework.NewUnstructured(wildwestv1alpha1.SchemeGroupVersion.WithKind("Sheriff"), "wyatt", "", map[string]interface{}{
+		"intent": "keep the peace",
+	})
+
+	t.Logf("Applying sheriff %s", sheriff.GetName())
+	sheriffs := dynamicClusterClient.Cluster(wsPath).Resource(wildwestv1alpha1.SchemeGroupVersion.WithResource("sheriffs"))
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		_, err := sheriffs.Apply(ctx, sheriff.GetName(), sheriff, metav1.ApplyOptions{FieldManager: "e2e-test-runner"})
+		if err != nil {
+			return false, fmt.Sprintf("failed to apply sheriff: %v", err)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "failed to apply sheriff %s", sheriff.GetName())
+
+	got, err := sheriffs.Get(ctx, sheriff.GetName(), metav1.GetOptions{})
+	require.NoError(t, err, "failed to get sheriff %s", sheriff.GetName())
+	require.Equal(t, "Sheriff", got.GetKind())
+	intent, _, err := unstructured.NestedString(got.Object, "spec", "intent")
+	require.NoError(t, err)
+	require.Equal(t, "keep the peace", intent)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestWatchEventLatency(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct dynamic cluster client")
+
+	gvr := corev1.SchemeGroupVersion.WithResource("configmaps")
+	client := dynamicClusterClient.Cluster(wsPath)
+	latency := framework.WatchLatency(ctx, t, client, gvr, func(ctx context.Context) (*unstructured.Unstructured, error) {
+		cm := framework.NewUnstructured(corev1.SchemeGroupVersion.WithKind("ConfigMap"), "watch-latency", metav1.NamespaceDefault, nil)
+		return client.Resource(gvr).Namespace(metav1.NamespaceDefault).Create(ctx, cm, metav1.CreateOptions{})
+	})
+
+	// the bound is generous, shared servers in CI are busy
+	require.Less(t, latency, 5*time.Second, "ADDED event for the ConfigMap took too long")
+}
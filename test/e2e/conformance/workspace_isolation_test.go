@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestWorkspaceIsolation(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	cfg := server.BaseConfig(t)
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct dynamic cluster client")
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kube cluster client")
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsA, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+	wsB, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	for _, path := range []logicalcluster.Path{wsA, wsB} {
+		_, err := kubeClusterClient.Cluster(path).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "isolation"}}, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create namespace in %s", path)
+	}
+
+	t.Run("namespaced", func(t *testing.T) {
+		gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+		framework.AssertWorkspaceIsolation(ctx, t, dynamicClusterClient, wsA, wsB, gvr, func(ctx context.Context, path logicalcluster.Path) (*unstructured.Unstructured, error) {
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion("v1")
+			obj.SetKind("ConfigMap")
+			obj.SetName("isolated")
+			obj.SetNamespace("isolation")
+			return dynamicClusterClient.Cluster(path).Resource(gvr).Namespace("isolation").Create(ctx, obj, metav1.CreateOptions{})
+		})
+	})
+
+	t.Run("cluster-scoped", func(t *testing.T) {
+		gvr := schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterroles"}
+		framework.AssertWorkspaceIsolation(ctx, t, dynamicClusterClient, wsA, wsB, gvr, func(ctx context.Context, path logicalcluster.Path) (*unstructured.Unstructured, error) {
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion("rbac.authorization.k8s.io/v1")
+			obj.SetKind("ClusterRole")
+			obj.SetName("isolated")
+			return dynamicClusterClient.Cluster(path).Resource(gvr).Create(ctx, obj, metav1.CreateOptions{})
+		})
+	})
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresourcedefinition
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+
+	kcpapiextensionsclientset "github.com/kcp-dev/client-go/apiextensions/client"
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	webhookserver "github.com/kcp-dev/kcp/test/e2e/fixtures/webhook"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestCustomResourceConversionWebhook(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	cfg := server.BaseConfig(t)
+	crdClusterClient, err := kcpapiextensionsclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct apiextensions cluster client for server")
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct dynamic cluster client for server")
+
+	// v1 spells the field "color", v2 "colour".
+	testWebhook := &webhookserver.ConversionWebhookServer{
+		ConvertFn: func(obj *unstructured.Unstructured, desiredAPIVersion string) error {
+			from, to := "colour", "color"
+			if desiredAPIVersion == "conversion.e2e.kcp.io/v2" {
+				from, to = to, from
+			}
+			value, found, err := unstructured.NestedString(obj.Object, "spec", from)
+			if err != nil || !found {
+				return err
+			}
+			unstructured.RemoveNestedField(obj.Object, "spec", from)
+			return unstructured.SetNestedField(obj.Object, value, "spec", to)
+		},
+	}
+	port, err := kcptestingserver.GetFreePort(t)
+	require.NoError(t, err, "failed to get free port for test webhook")
+	dirPath := filepath.Dir(server.KubeconfigPath())
+	testWebhook.StartTLS(t, filepath.Join(dirPath, "apiserver.crt"), filepath.Join(dirPath, "apiserver.key"), port)
+
+	url := testWebhook.GetURL()
+	versionSchema := &apiextensionsv1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"spec": {Type: "object", XPreserveUnknownFields: ptr.To(true)},
+			},
+		},
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets.conversion.e2e.kcp.io"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "conversion.e2e.kcp.io",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:   "widgets",
+				Singular: "widget",
+				Kind:     "Widget",
+				ListKind: "WidgetList",
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true, Storage: false, Schema: versionSchema},
+				{Name: "v2", Served: true, Storage: true, Schema: versionSchema},
+			},
+			Conversion: &apiextensionsv1.CustomResourceConversion{
+				Strategy: apiextensionsv1.WebhookConverter,
+				Webhook: &apiextensionsv1.WebhookConversion{
+					ClientConfig: &apiextensionsv1.WebhookClientConfig{
+						URL:      &url,
+						CABundle: cfg.CAData,
+					},
+					ConversionReviewVersions: []string{"v1"},
+				},
+			},
+		},
+	}
+	t.Logf("Creating multi-version CRD %s with a conversion webhook in %s", crd.Name, wsPath)
+	_, err = crdClusterClient.Cluster(wsPath).ApiextensionsV1().CustomResourceDefinitions().Create(ctx, crd, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create CRD")
+
+	v1 := schema.GroupVersionResource{Group: "conversion.e2e.kcp.io", Version: "v1", Resource: "widgets"}
+	v2 := v1.GroupResource().WithVersion("v2")
+
+	t.Logf("Creating a widget at the storage version v2")
+	widget := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "conversion.e2e.kcp.io/v2",
+		"kind":       "Widget",
+		"metadata":   map[string]interface{}{"name": "converted"},
+		"spec":       map[string]interface{}{"colour": "blue"},
+	}}
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		_, err := dynamicClusterClient.Cluster(wsPath).Resource(v2).Namespace("default").Create(ctx, widget, metav1.CreateOptions{})
+		if err != nil {
+			return false, fmt.Sprintf("failed to create widget: %v", err)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "widget could not be created")
+
+	t.Logf("Reading the widget as v1 and expecting the webhook to convert it")
+	var got *unstructured.Unstructured
+	framework.AssertConversionInvoked(t, testWebhook, "conversion.e2e.kcp.io/v1", func() {
+		got, err = dynamicClusterClient.Cluster(wsPath).Resource(v1).Namespace("default").Get(ctx, "converted", metav1.GetOptions{})
+		require.NoError(t, err, "failed to get widget as v1")
+	})
+	require.Equal(t, "conversion.e2e.kcp.io/v1", got.GetAPIVersion())
+	color, _, err := unstructured.NestedString(got.Object, "spec", "color")
+	require.NoError(t, err)
+	require.Equal(t, "blue", color, "widget was not converted by the webhook")
+}
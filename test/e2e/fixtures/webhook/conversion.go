@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ConversionWebhookServer is a CRD conversion webhook. It converts objects by
+// setting their apiVersion to the desired one, after applying ConvertFn if set,
+// and records the desired API version of every ConversionReview.
+type ConversionWebhookServer struct {
+	// ConvertFn, if set, converts obj in place to desiredAPIVersion.
+	ConvertFn func(obj *unstructured.Unstructured, desiredAPIVersion string) error
+
+	t *testing.T
+
+	port               string
+	lock               sync.Mutex
+	desiredAPIVersions []string
+}
+
+func (s *ConversionWebhookServer) StartTLS(t *testing.T, certFile, keyFile string, port string) {
+	t.Helper()
+
+	s.t = t
+	s.port = port
+
+	serveTLS(t, s, certFile, keyFile, port)
+}
+
+func (s *ConversionWebhookServer) GetURL() string {
+	return fmt.Sprintf("https://localhost:%v/convert", s.port)
+}
+
+func (s *ConversionWebhookServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		msg := fmt.Sprintf("Request could not be read: %v", err)
+		s.t.Logf("%v", msg)
+		http.Error(resp, msg, http.StatusBadRequest)
+		return
+	}
+
+	review := &apiextensionsv1.ConversionReview{}
+	if err := json.Unmarshal(data, review); err != nil || review.Request == nil {
+		msg := fmt.Sprintf("Expected ConversionReview with request, got error: %v", err)
+		s.t.Logf("%v", msg)
+		http.Error(resp, msg, http.StatusBadRequest)
+		return
+	}
+	desired := review.Request.DesiredAPIVersion
+
+	response := &apiextensionsv1.ConversionResponse{
+		UID:    review.Request.UID,
+		Result: metav1.Status{Status: metav1.StatusSuccess},
+	}
+	for _, raw := range review.Request.Objects {
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(raw.Raw); err != nil {
+			response.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			break
+		}
+		if s.ConvertFn != nil {
+			if err := s.ConvertFn(obj, desired); err != nil {
+				response.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+				break
+			}
+		}
+		obj.SetAPIVersion(desired)
+		converted, err := obj.MarshalJSON()
+		if err != nil {
+			response.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			break
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	if response.Result.Status != metav1.StatusSuccess {
+		response.ConvertedObjects = nil
+	}
+
+	respBytes, err := json.Marshal(&apiextensionsv1.ConversionReview{
+		TypeMeta: review.TypeMeta,
+		Response: response,
+	})
+	if err != nil {
+		s.t.Logf("%v", err)
+		http.Error(resp, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.desiredAPIVersions = append(s.desiredAPIVersions, desired)
+
+	resp.Header().Set("Content-Type", "application/json")
+	if _, err := resp.Write(respBytes); err != nil {
+		s.t.Logf("%v", err)
+	}
+}
+
+func (s *ConversionWebhookServer) Calls() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.desiredAPIVersions)
+}
+
+// DesiredAPIVersions returns the desired API version of every conversion
+// request received so far, in order.
+func (s *ConversionWebhookServer) DesiredAPIVersions() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]string(nil), s.desiredAPIVersions...)
+}
@@ -49,7 +49,14 @@ func (s *AdmissionWebhookServer) StartTLS(t *testing.T, certFile, keyFile string
 	s.t = t
 	s.port = port
 
-	serv := &http.Server{Addr: fmt.Sprintf(":%v", port), Handler: s}
+	serveTLS(t, s, certFile, keyFile, port)
+}
+
+// serveTLS serves handler on the given port until the test ends.
+func serveTLS(t *testing.T, handler http.Handler, certFile, keyFile string, port string) {
+	t.Helper()
+
+	serv := &http.Server{Addr: fmt.Sprintf(":%v", port), Handler: handler}
 	t.Cleanup(func() {
 		t.Log("Shutting down the HTTP server")
 		err := serv.Shutdown(context.TODO())
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+)
+
+// AccessLogEntry is a request recorded in the access log of a server.
+type AccessLogEntry struct {
+	Verb      string
+	URI       string
+	Latency   time.Duration
+	UserAgent string
+	AuditID   string
+	SrcIP     string
+	// Status is the response code, or 0 if the connection was hijacked, e.g.
+	// for a websocket.
+	Status int
+}
+
+// ParseAccessLog parses the access log of a server started with
+// WithAccessLog, including rotated files, in the order the requests finished.
+func ParseAccessLog(server kcptestingserver.RunningServer) ([]AccessLogEntry, error) {
+	path := kcptestingserver.AccessLogPath(server)
+	if path == "" {
+		return nil, fmt.Errorf("server %s was not started with WithAccessLog", server.Name())
+	}
+
+	var entries []AccessLogEntry
+	for _, file := range kcptestingserver.AccessLogFiles(path) {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(nil, 1<<20)
+		for scanner.Scan() {
+			entry, err := parseAccessLogLine(scanner.Text())
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+			}
+			entries = append(entries, entry)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+	}
+	return entries, nil
+}
+
+// parseAccessLogLine parses a klog line of the form
+//
+//	I1014 12:00:00.000000 42 httplog.go:134] "HTTP" verb="GET" URI="/readyz" latency="1.2ms" ... resp=200
+func parseAccessLogLine(line string) (AccessLogEntry, error) {
+	_, kvs, found := strings.Cut(line, `] "HTTP" `)
+	if !found {
+		return AccessLogEntry{}, fmt.Errorf("not an access log line: %q", line)
+	}
+
+	var entry AccessLogEntry
+	for kvs != "" {
+		key, rest, found := strings.Cut(kvs, "=")
+		if !found {
+			return AccessLogEntry{}, fmt.Errorf("invalid key-value pair %q", kvs)
+		}
+
+		var value string
+		switch {
+		case strings.HasPrefix(rest, `"`):
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return AccessLogEntry{}, fmt.Errorf("invalid quoted value of %s: %w", key, err)
+			}
+			value, _ = strconv.Unquote(quoted)
+			rest = rest[len(quoted):]
+		case strings.HasPrefix(rest, "<"):
+			// multi-line values continue on the next lines, which are not part
+			// of the access log
+			rest = ""
+		default:
+			value, rest, _ = strings.Cut(rest, " ")
+		}
+		kvs = strings.TrimLeft(rest, " ")
+
+		var err error
+		switch key {
+		case "verb":
+			entry.Verb = value
+		case "URI":
+			entry.URI = value
+		case "latency":
+			entry.Latency, err = time.ParseDuration(value)
+		case "userAgent":
+			entry.UserAgent = value
+		case "audit-ID":
+			entry.AuditID = value
+		case "srcIP":
+			entry.SrcIP = value
+		case "resp":
+			entry.Status, err = strconv.Atoi(value)
+		}
+		if err != nil {
+			return AccessLogEntry{}, fmt.Errorf("invalid value of %s: %w", key, err)
+		}
+	}
+	return entry, nil
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	apisv1alpha2 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+)
+
+// ShareAPIExport creates the APIExport exportName with the given spec in
+// producerPath and binds it into each of consumerPaths, modelling one provider
+// with many consumers. The APIResourceSchemas referenced by spec must already
+// exist in producerPath. It waits for every binding to be Bound and returns them
+// keyed by consumer path.
+func ShareAPIExport(ctx context.Context, t *testing.T, client kcpclientset.ClusterInterface, producerPath logicalcluster.Path, consumerPaths []logicalcluster.Path, exportName string, spec apisv1alpha2.APIExportSpec) map[logicalcluster.Path]*apisv1alpha1.APIBinding {
+	t.Helper()
+
+	t.Logf("Creating APIExport %s|%s", producerPath, exportName)
+	_, err := client.Cluster(producerPath).ApisV1alpha2().APIExports().Create(ctx, &apisv1alpha2.APIExport{
+		ObjectMeta: metav1.ObjectMeta{Name: exportName},
+		Spec:       spec,
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create APIExport %s|%s", producerPath, exportName)
+
+	binding := &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: exportName},
+		Spec: apisv1alpha1.APIBindingSpec{
+			Reference: apisv1alpha1.BindingReference{
+				Export: &apisv1alpha1.ExportBindingReference{
+					Path: producerPath.String(),
+					Name: exportName,
+				},
+			},
+		},
+	}
+	for _, consumerPath := range consumerPaths {
+		t.Logf("Binding APIExport %s|%s into %s", producerPath, exportName, consumerPath)
+		kcptestinghelpers.Eventually(t, func() (bool, string) {
+			_, err := client.Cluster(consumerPath).ApisV1alpha1().APIBindings().Create(ctx, binding, metav1.CreateOptions{})
+			if err != nil {
+				return false, fmt.Sprintf("failed to create APIBinding %s|%s: %v", consumerPath, binding.Name, err)
+			}
+			return true, ""
+		}, wait.ForeverTestTimeout, 100*time.Millisecond, "APIBinding %s|%s could not be created", consumerPath, binding.Name)
+	}
+
+	bound := make(map[logicalcluster.Path]*apisv1alpha1.APIBinding, len(consumerPaths))
+	for _, consumerPath := range consumerPaths {
+		kcptestinghelpers.Eventually(t, func() (bool, string) {
+			b, err := client.Cluster(consumerPath).ApisV1alpha1().APIBindings().Get(ctx, binding.Name, metav1.GetOptions{})
+			if err != nil {
+				return false, fmt.Sprintf("failed to get APIBinding: %v", err)
+			}
+			if b.Status.Phase != apisv1alpha1.APIBindingPhaseBound {
+				return false, fmt.Sprintf("APIBinding is in phase %q", b.Status.Phase)
+			}
+			bound[consumerPath] = b
+			return true, ""
+		}, wait.ForeverTestTimeout, 100*time.Millisecond, "APIBinding %s|%s did not become bound", consumerPath, binding.Name)
+	}
+	return bound
+}
+
+// SetAPIExportPermissionClaims sets the permission claims of the APIExport
+// exportName in path to claims. Every APIBinding of the export receives them in
+// its status.exportPermissionClaims, see WaitForExportPermissionClaims.
+func SetAPIExportPermissionClaims(ctx context.Context, t *testing.T, client kcpclientset.ClusterInterface, path logicalcluster.Path, exportName string, claims []apisv1alpha2.PermissionClaim) {
+	t.Helper()
+
+	t.Logf("Setting the permission claims of APIExport %s|%s to %v", path, exportName, claims)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		export, err := client.Cluster(path).ApisV1alpha2().APIExports().Get(ctx, exportName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		export.Spec.PermissionClaims = claims
+		_, err = client.Cluster(path).ApisV1alpha2().APIExports().Update(ctx, export, metav1.UpdateOptions{})
+		return err
+	})
+	require.NoError(t, err, "failed to set the permission claims of APIExport %s|%s", path, exportName)
+}
+
+// WaitForExportPermissionClaims waits until status.exportPermissionClaims of
+// the APIBinding bindingName in path lists exactly the given claims of its
+// APIExport, and returns the binding.
+func WaitForExportPermissionClaims(ctx context.Context, t *testing.T, client kcpclientset.ClusterInterface, path logicalcluster.Path, bindingName string, claims []apisv1alpha2.PermissionClaim) *apisv1alpha1.APIBinding {
+	t.Helper()
+
+	expected := make([]apisv1alpha1.PermissionClaim, 0, len(claims))
+	for _, claim := range claims {
+		var v1Claim apisv1alpha1.PermissionClaim
+		err := apisv1alpha2.Convert_v1alpha2_PermissionClaim_To_v1alpha1_PermissionClaim(&claim, &v1Claim, nil)
+		require.NoError(t, err, "failed to convert permission claim %s", claim)
+		expected = append(expected, v1Claim)
+	}
+
+	var binding *apisv1alpha1.APIBinding
+	t.Logf("Waiting for APIBinding %s|%s to list the export permission claims %v", path, bindingName, expected)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		var err error
+		binding, err = client.Cluster(path).ApisV1alpha1().APIBindings().Get(ctx, bindingName, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Sprintf("failed to get APIBinding: %v", err)
+		}
+		if !equality.Semantic.DeepEqual(expected, binding.Status.ExportPermissionClaims) {
+			return false, fmt.Sprintf("APIBinding lists the export permission claims %v", binding.Status.ExportPermissionClaims)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "APIBinding %s|%s did not list the export permission claims", path, bindingName)
+	return binding
+}
+
+// AcceptPermissionClaims accepts the given claims in the spec of the
+// APIBinding bindingName in path and waits until all of them are listed in
+// status.appliedPermissionClaims. It returns the binding.
+func AcceptPermissionClaims(ctx context.Context, t *testing.T, client kcpclientset.ClusterInterface, path logicalcluster.Path, bindingName string, claims []apisv1alpha1.PermissionClaim) *apisv1alpha1.APIBinding {
+	t.Helper()
+
+	return setPermissionClaimsState(ctx, t, client, path, bindingName, claims, apisv1alpha1.ClaimAccepted)
+}
+
+// RejectPermissionClaims rejects the given claims in the spec of the
+// APIBinding bindingName in path and waits until none of them is listed in
+// status.appliedPermissionClaims anymore. It returns the binding.
+func RejectPermissionClaims(ctx context.Context, t *testing.T, client kcpclientset.ClusterInterface, path logicalcluster.Path, bindingName string, claims []apisv1alpha1.PermissionClaim) *apisv1alpha1.APIBinding {
+	t.Helper()
+
+	return setPermissionClaimsState(ctx, t, client, path, bindingName, claims, apisv1alpha1.ClaimRejected)
+}
+
+func setPermissionClaimsState(ctx context.Context, t *testing.T, client kcpclientset.ClusterInterface, path logicalcluster.Path, bindingName string, claims []apisv1alpha1.PermissionClaim, state apisv1alpha1.AcceptablePermissionClaimState) *apisv1alpha1.APIBinding {
+	t.Helper()
+
+	bindings := client.Cluster(path).ApisV1alpha1().APIBindings()
+
+	t.Logf("Setting permission claims %v of APIBinding %s|%s to %s", claims, path, bindingName, state)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		binding, err := bindings.Get(ctx, bindingName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+	claims:
+		for _, claim := range claims {
+			for i := range binding.Spec.PermissionClaims {
+				if equality.Semantic.DeepEqual(binding.Spec.PermissionClaims[i].PermissionClaim, claim) {
+					binding.Spec.PermissionClaims[i].State = state
+					continue claims
+				}
+			}
+			binding.Spec.PermissionClaims = append(binding.Spec.PermissionClaims, apisv1alpha1.AcceptablePermissionClaim{
+				PermissionClaim: claim,
+				State:           state,
+			})
+		}
+		_, err = bindings.Update(ctx, binding, metav1.UpdateOptions{})
+		return err
+	})
+	require.NoError(t, err, "failed to set permission claims of APIBinding %s|%s to %s", path, bindingName, state)
+
+	var binding *apisv1alpha1.APIBinding
+	t.Logf("Waiting for the applied permission claims of APIBinding %s|%s to reflect the %s claims", path, bindingName, state)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		var err error
+		binding, err = bindings.Get(ctx, bindingName, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Sprintf("failed to get APIBinding: %v", err)
+		}
+		for _, claim := range claims {
+			applied := false
+			for _, appliedClaim := range binding.Status.AppliedPermissionClaims {
+				if equality.Semantic.DeepEqual(appliedClaim, claim) {
+					applied = true
+					break
+				}
+			}
+			if applied != (state == apisv1alpha1.ClaimAccepted) {
+				return false, fmt.Sprintf("APIBinding lists the applied permission claims %v", binding.Status.AppliedPermissionClaims)
+			}
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "applied permission claims of APIBinding %s|%s did not reflect the %s claims", path, bindingName, state)
+	return binding
+}
+
+// AssertInheritedBindings waits until the workspace at path has a Bound
+// APIBinding with a completed initial binding for each of the expected exports,
+// as created from the defaultAPIBindings of its WorkspaceType and the types it
+// extends. Exports are matched by the path and name in the spec of the
+// binding, i.e. as written in the WorkspaceType. Other bindings are ignored.
+func AssertInheritedBindings(ctx context.Context, t *testing.T, client kcpclientset.ClusterInterface, path logicalcluster.Path, expectedExports []tenancyv1alpha1.APIExportReference) {
+	t.Helper()
+
+	t.Logf("Waiting for workspace %s to have bindings for %v", path, expectedExports)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		bindings, err := client.Cluster(path).ApisV1alpha1().APIBindings().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return false, fmt.Sprintf("failed to list APIBindings: %v", err)
+		}
+	exports:
+		for _, export := range expectedExports {
+			for _, binding := range bindings.Items {
+				ref := binding.Spec.Reference.Export
+				if ref == nil || ref.Path != export.Path || ref.Name != export.Export {
+					continue
+				}
+				if binding.Status.Phase != apisv1alpha1.APIBindingPhaseBound || !conditions.IsTrue(&binding, apisv1alpha1.InitialBindingCompleted) {
+					return false, fmt.Sprintf("APIBinding %s for APIExport %s|%s is in phase %q", binding.Name, export.Path, export.Export, binding.Status.Phase)
+				}
+				continue exports
+			}
+			return false, fmt.Sprintf("no APIBinding for APIExport %s|%s", export.Path, export.Export)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "workspace %s did not get bindings for %v", path, expectedExports)
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+)
+
+// ApplyFunc server-side applies an object with a fixed field manager, forcing
+// conflicts if force is set, and returns the applied object.
+type ApplyFunc func(ctx context.Context, force bool) (metav1.Object, error)
+
+// AssertApplyConflict verifies server-side apply conflict handling between two
+// field managers. applyFnA is applied first and must succeed. applyFnB must set
+// a conflicting value for a field owned by A and must not have applied the
+// object before: without force it has to fail with 409 Conflict, with force it
+// has to succeed and take over the fields it sets from every other applier.
+func AssertApplyConflict(ctx context.Context, t *testing.T, applyFnA, applyFnB ApplyFunc) {
+	t.Helper()
+
+	t.Logf("Applying with manager A")
+	objA, err := applyFnA(ctx, false)
+	require.NoError(t, err, "failed to apply with manager A")
+	appliersA := appliedFields(t, objA)
+	require.NotEmpty(t, appliersA, "object applied by manager A has no apply managed fields")
+
+	t.Logf("Applying a conflicting value with manager B without force")
+	_, err = applyFnB(ctx, false)
+	require.Error(t, err, "expected apply of manager B to conflict")
+	require.True(t, apierrors.IsConflict(err), "expected a 409 Conflict, got: %v", err)
+
+	t.Logf("Applying the conflicting value with manager B with force")
+	objB, err := applyFnB(ctx, true)
+	require.NoError(t, err, "failed to force apply with manager B")
+	appliersB := appliedFields(t, objB)
+	var managerB string
+	for manager := range appliersB {
+		if _, found := appliersA[manager]; !found {
+			require.Empty(t, managerB, "found more than one new apply manager: %s and %s", managerB, manager)
+			managerB = manager
+		}
+	}
+	require.NotEmpty(t, managerB, "manager B does not own any fields after force apply")
+
+	owned := appliersB[managerB]
+	for manager, fields := range appliersB {
+		if manager == managerB {
+			continue
+		}
+		require.True(t, fields.Intersection(owned).Empty(), "manager %s still owns fields of manager %s after force apply: %s", manager, managerB, fields.Intersection(owned))
+	}
+}
+
+// AssertApplyRoundTrip verifies that the generated apply configuration of obj
+// round-trips losslessly through the server. extract, e.g. the generated
+// ExtractAPIBindingStatus, turns obj into the apply configuration owned by
+// fieldManager, which apply then has to server-side apply with fieldManager.
+// Both the extracted configuration and the fields owned by fieldManager must be
+// the same before and after. fieldManager must have applied obj before. The
+// applied object is returned.
+func AssertApplyRoundTrip[T metav1.Object, C any](ctx context.Context, t *testing.T, obj T, fieldManager string, extract func(obj T, fieldManager string) (C, error), apply func(ctx context.Context, config C) (T, error)) T {
+	t.Helper()
+
+	owned, found := appliedFields(t, obj)[fieldManager]
+	require.True(t, found, "%s does not own any fields of %s", fieldManager, obj.GetName())
+	config, err := extract(obj, fieldManager)
+	require.NoError(t, err, "failed to extract apply configuration of %s for %s", obj.GetName(), fieldManager)
+
+	t.Logf("Applying the apply configuration of %s extracted for %s", obj.GetName(), fieldManager)
+	applied, err := apply(ctx, config)
+	require.NoError(t, err, "failed to apply extracted apply configuration of %s", obj.GetName())
+
+	roundTripped, err := extract(applied, fieldManager)
+	require.NoError(t, err, "failed to extract apply configuration of applied %s for %s", obj.GetName(), fieldManager)
+	require.Equal(t, config, roundTripped, "apply configuration of %s for %s changed by the round-trip", obj.GetName(), fieldManager)
+
+	ownedAfter, found := appliedFields(t, applied)[fieldManager]
+	require.True(t, found, "%s does not own any fields of %s after the round-trip", fieldManager, obj.GetName())
+	require.True(t, owned.Equals(ownedAfter), "fields of %s owned by %s changed by the round-trip:\nbefore: %s\nafter: %s", obj.GetName(), fieldManager, owned, ownedAfter)
+
+	return applied
+}
+
+// appliedFields returns the fields owned by each apply manager of obj.
+func appliedFields(t *testing.T, obj metav1.Object) map[string]*fieldpath.Set {
+	t.Helper()
+
+	managers := map[string]*fieldpath.Set{}
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Operation != metav1.ManagedFieldsOperationApply || entry.FieldsV1 == nil {
+			continue
+		}
+		fields := parseFieldsV1(t, entry)
+		if existing, found := managers[entry.Manager]; found {
+			fields = fields.Union(existing)
+		}
+		managers[entry.Manager] = fields
+	}
+	return managers
+}
+
+// ManagedFields returns the managed fields of the object returned by getFn.
+func ManagedFields(ctx context.Context, t *testing.T, getFn func(ctx context.Context) (metav1.Object, error)) []metav1.ManagedFieldsEntry {
+	t.Helper()
+
+	obj, err := getFn(ctx)
+	require.NoError(t, err, "failed to get object")
+	return obj.GetManagedFields()
+}
+
+// AssertFieldOwner fails unless manager is among the owners of the field at
+// fieldPath of obj. fieldPath is a dot separated path of field names, e.g.
+// "spec.replicas" or "data.key"; list elements cannot be addressed.
+func AssertFieldOwner(t *testing.T, obj metav1.Object, fieldPath, manager string) {
+	t.Helper()
+
+	var elements []any
+	for _, name := range strings.Split(strings.TrimPrefix(fieldPath, "."), ".") {
+		require.NotEmpty(t, name, "invalid field path %q", fieldPath)
+		elements = append(elements, name)
+	}
+	path := fieldpath.MakePathOrDie(elements...)
+
+	var owners []string
+	for _, entry := range obj.GetManagedFields() {
+		if entry.FieldsV1 == nil {
+			continue
+		}
+		if parseFieldsV1(t, entry).Has(path) {
+			owners = append(owners, entry.Manager)
+		}
+	}
+	sort.Strings(owners)
+	require.Contains(t, owners, manager, "%s of %s is not owned by %s", fieldPath, obj.GetName(), manager)
+}
+
+// AssertMetadataPreserved applies with applyFn and verifies that the labels
+// and annotations of the object returned by getFn are exactly the expected
+// ones afterwards: none are dropped and none are added. Annotations kcp sets
+// itself, i.e. those of the kcp.io domain and its subdomains like kcp.io/cluster,
+// are ignored, as is the kubernetes.io/metadata.name label of namespaces.
+func AssertMetadataPreserved(ctx context.Context, t *testing.T, applyFn ApplyFunc, getFn func(ctx context.Context) (metav1.Object, error), expectedLabels, expectedAnnotations map[string]string) {
+	t.Helper()
+
+	_, err := applyFn(ctx, false)
+	require.NoError(t, err, "failed to apply object")
+	obj, err := getFn(ctx)
+	require.NoError(t, err, "failed to get object")
+
+	labels := map[string]string{}
+	for k, v := range obj.GetLabels() {
+		if k != corev1.LabelMetadataName {
+			labels[k] = v
+		}
+	}
+	annotations := map[string]string{}
+	for k, v := range obj.GetAnnotations() {
+		if !isSystemAnnotation(k) {
+			annotations[k] = v
+		}
+	}
+	if expectedLabels == nil {
+		expectedLabels = map[string]string{}
+	}
+	if expectedAnnotations == nil {
+		expectedAnnotations = map[string]string{}
+	}
+	require.Equal(t, expectedLabels, labels, "unexpected labels on %s after apply", obj.GetName())
+	require.Equal(t, expectedAnnotations, annotations, "unexpected annotations on %s after apply", obj.GetName())
+}
+
+func isSystemAnnotation(key string) bool {
+	domain, _, found := strings.Cut(key, "/")
+	return found && (domain == "kcp.io" || strings.HasSuffix(domain, ".kcp.io"))
+}
+
+func parseFieldsV1(t *testing.T, entry metav1.ManagedFieldsEntry) *fieldpath.Set {
+	t.Helper()
+
+	fields := &fieldpath.Set{}
+	require.NoError(t, fields.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)), "failed to parse managed fields of %s", entry.Manager)
+	return fields
+}
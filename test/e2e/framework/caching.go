@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/rest"
+)
+
+// AssertCachingHeaders requests path, e.g. /clusters/root/apis, from the server
+// behind cfg and verifies that the response carries Cache-Control and ETag
+// headers, and that a conditional request with the ETag in If-None-Match gets
+// a 304 Not Modified. It returns the headers of the first response.
+func AssertCachingHeaders(ctx context.Context, t *testing.T, cfg *rest.Config, path string) http.Header {
+	t.Helper()
+
+	client, err := rest.HTTPClientFor(cfg)
+	require.NoError(t, err, "failed to construct HTTP client")
+
+	get := func(header http.Header) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(cfg.Host, "/")+path, nil)
+		require.NoError(t, err)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		resp, err := client.Do(req)
+		require.NoError(t, err, "failed to get %s", path)
+		defer resp.Body.Close()
+		_, err = io.Copy(io.Discard, resp.Body)
+		require.NoError(t, err, "failed to read response of %s", path)
+		return resp
+	}
+
+	resp := get(http.Header{"Accept": []string{"application/json"}})
+	require.Equal(t, http.StatusOK, resp.StatusCode, "unexpected status of %s", path)
+	t.Logf("Caching headers of %s: Cache-Control=%q ETag=%q", path, resp.Header.Get("Cache-Control"), resp.Header.Get("ETag"))
+	require.NotEmpty(t, resp.Header.Get("Cache-Control"), "%s has no Cache-Control header", path)
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag, "%s has no ETag header", path)
+
+	notModified := get(http.Header{"Accept": []string{"application/json"}, "If-None-Match": []string{etag}})
+	require.Equal(t, http.StatusNotModified, notModified.StatusCode, "conditional request for %s with ETag %s was not answered with 304", path, etag)
+
+	return resp.Header
+}
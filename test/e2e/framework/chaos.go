@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+)
+
+// chaosRestartTimeout bounds how long ChaosRestart waits for the shard to come
+// back and the operations to succeed.
+const chaosRestartTimeout = 5 * time.Minute
+
+// ChaosRestart kills and restarts the named shard of server while calling
+// during over and over, and asserts that during eventually succeeds once the
+// shard is ready again. during should issue client operations, e.g. through
+// the front-proxy or against the root shard. Its failures while the shard is
+// down are expected and only counted. ChaosRestart returns the number of failed
+// calls.
+func ChaosRestart(ctx context.Context, t *testing.T, server kcptestingserver.RunningServer, shard string, during func(ctx context.Context) error) int {
+	t.Helper()
+
+	restartErr := make(chan error, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		restartErr <- kcptestingserver.RestartShard(t, server, shard)
+	}()
+	// the restart must not outlive the test, even if it fails
+	t.Cleanup(func() { <-done })
+
+	var restartResult error
+	restarted := false
+
+	var calls, failures int
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		if !restarted {
+			select {
+			case restartResult = <-restartErr:
+				restarted = true
+				if restartResult != nil {
+					return true, "" // fail below
+				}
+			default:
+			}
+		}
+		calls++
+		if err := during(ctx); err != nil {
+			failures++
+			return false, fmt.Sprintf("operation failed: %v", err)
+		}
+		return restarted, "shard is still restarting"
+	}, chaosRestartTimeout, 100*time.Millisecond, "operations did not succeed after restarting shard %s", shard)
+	require.NoError(t, restartResult, "failed to restart shard %s", shard)
+
+	t.Logf("Shard %s restarted, %d of %d operations failed during the restart", shard, failures, calls)
+	return failures
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+)
+
+// AssertConsistentRead reads the same object through clientA and clientB with
+// getFn, e.g. through the front-proxy and directly from a shard, or from two
+// shards of which one serves a replica from the cache server. It waits for both
+// to return the same resourceVersion, as replicas can lag behind, and then
+// fails unless both returned the same content.
+func AssertConsistentRead[C any](ctx context.Context, t *testing.T, clientA, clientB C, getFn func(ctx context.Context, client C) (metav1.Object, error)) {
+	t.Helper()
+
+	var objA, objB metav1.Object
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		var err error
+		objA, err = getFn(ctx, clientA)
+		if err != nil {
+			return false, fmt.Sprintf("failed to read through the first client: %v", err)
+		}
+		objB, err = getFn(ctx, clientB)
+		if err != nil {
+			return false, fmt.Sprintf("failed to read through the second client: %v", err)
+		}
+		if objA.GetResourceVersion() != objB.GetResourceVersion() {
+			return false, fmt.Sprintf("resourceVersion %s read through the first client differs from %s read through the second", objA.GetResourceVersion(), objB.GetResourceVersion())
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "clients did not converge on the same resourceVersion")
+
+	t.Logf("Both clients read %s at resourceVersion %s", objA.GetName(), objA.GetResourceVersion())
+	require.Equal(t, objA, objB, "clients read different content at the same resourceVersion")
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	webhookserver "github.com/kcp-dev/kcp/test/e2e/fixtures/webhook"
+)
+
+// AssertConversionInvoked runs op and fails unless webhook was asked to
+// convert objects to desiredAPIVersion, e.g. "example.io/v1", while op ran.
+func AssertConversionInvoked(t *testing.T, webhook *webhookserver.ConversionWebhookServer, desiredAPIVersion string, op func()) {
+	t.Helper()
+
+	before := webhook.Calls()
+	op()
+	calls := webhook.DesiredAPIVersions()[before:]
+	require.NotEmpty(t, calls, "conversion webhook was not called")
+	require.Contains(t, calls, desiredAPIVersion, "conversion webhook was not asked to convert to %s", desiredAPIVersion)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// AssertDefaulted creates an object with createFn, usually with a minimal spec,
+// and fails unless both the object returned by the create and the object read
+// back with getFn have the expected default values. checks maps dot-separated
+// field paths, e.g. "spec.replicas", to their expected values, which are
+// compared by their JSON representation. This works for built-in types and for
+// custom resources with defaults in their schema alike.
+func AssertDefaulted(ctx context.Context, t *testing.T, createFn, getFn func(ctx context.Context) (runtime.Object, error), checks map[string]interface{}) {
+	t.Helper()
+
+	created, err := createFn(ctx)
+	require.NoError(t, err, "failed to create object")
+	assertFields(t, created, checks, "created")
+
+	got, err := getFn(ctx)
+	require.NoError(t, err, "failed to get object")
+	assertFields(t, got, checks, "read back")
+}
+
+func assertFields(t *testing.T, obj runtime.Object, checks map[string]interface{}, what string) {
+	t.Helper()
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	require.NoError(t, err, "failed to convert %s object", what)
+
+	for path, expected := range checks {
+		actual, found, err := unstructured.NestedFieldNoCopy(content, strings.Split(path, ".")...)
+		require.NoError(t, err, "failed to get %s of %s object", path, what)
+		require.True(t, found, "%s of %s object is not set", path, what)
+
+		expectedJSON, err := json.Marshal(expected)
+		require.NoError(t, err, "failed to marshal expected value of %s", path)
+		actualJSON, err := json.Marshal(actual)
+		require.NoError(t, err, "failed to marshal %s of %s object", path, what)
+		require.JSONEq(t, string(expectedJSON), string(actualJSON), "unexpected default of %s on %s object", path, what)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+)
+
+// DeleteCollection deletes all objects of gvr in the logical cluster
+// clusterPath matching the label selector with a single DeleteCollection call
+// and waits until none of them remain, e.g. because of finalizers. namespace
+// must be set for namespaced resources and empty for cluster-scoped ones.
+func DeleteCollection(ctx context.Context, t *testing.T, client kcpdynamic.ClusterInterface, gvr schema.GroupVersionResource, clusterPath logicalcluster.Path, namespace, selector string) {
+	t.Helper()
+
+	var resource dynamic.ResourceInterface = client.Cluster(clusterPath).Resource(gvr)
+	where := clusterPath.String()
+	if namespace != "" {
+		resource = client.Cluster(clusterPath).Resource(gvr).Namespace(namespace)
+		where = clusterPath.String() + "|" + namespace
+	}
+
+	listOpts := metav1.ListOptions{LabelSelector: selector}
+	t.Logf("Deleting %s in %s matching %q", gvr.Resource, where, selector)
+	err := resource.DeleteCollection(ctx, metav1.DeleteOptions{}, listOpts)
+	require.NoError(t, err, "failed to delete collection of %s in %s", gvr.Resource, where)
+
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		list, err := resource.List(ctx, listOpts)
+		if err != nil {
+			return false, fmt.Sprintf("failed to list %s: %v", gvr.Resource, err)
+		}
+		if len(list.Items) > 0 {
+			names := make([]string, 0, len(list.Items))
+			for _, item := range list.Items {
+				names = append(names, item.GetName())
+			}
+			return false, fmt.Sprintf("%s still exist: %v", gvr.Resource, names)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "%s in %s matching %q were not deleted", gvr.Resource, where, selector)
+}
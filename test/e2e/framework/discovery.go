@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcpdiscovery "github.com/kcp-dev/client-go/discovery"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+)
+
+// AssertDiscoveryHasResource waits until gvr is served in the discovery of
+// clusterPath, e.g. after binding an APIExport, and returns how long that took.
+// Discovery is fetched as a whole, i.e. aggregated discovery is used if the
+// server supports it.
+func AssertDiscoveryHasResource(ctx context.Context, t *testing.T, discoveryClusterClient kcpdiscovery.DiscoveryClusterInterface, clusterPath logicalcluster.Path, gvr schema.GroupVersionResource) time.Duration {
+	t.Helper()
+
+	start := time.Now()
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		if err := ctx.Err(); err != nil {
+			return false, err.Error()
+		}
+		// Failures of unrelated group versions are returned as partial errors
+		// and must not prevent finding gvr.
+		_, resourceLists, err := discoveryClusterClient.Cluster(clusterPath).ServerGroupsAndResources()
+		for _, resourceList := range resourceLists {
+			if resourceList.GroupVersion != gvr.GroupVersion().String() {
+				continue
+			}
+			for _, resource := range resourceList.APIResources {
+				if resource.Name == gvr.Resource {
+					return true, ""
+				}
+			}
+		}
+		return false, fmt.Sprintf("%s not in discovery yet (error: %v)", gvr, err)
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "%s did not appear in the discovery of %s", gvr, clusterPath)
+	elapsed := time.Since(start)
+
+	t.Logf("%s appeared in the discovery of %s after %s", gvr, clusterPath, elapsed)
+	return elapsed
+}
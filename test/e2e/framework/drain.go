@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+)
+
+// DrainServer gracefully shuts down server while a request is in flight and
+// asserts that the request completes successfully during the drain. request is
+// called with a root shard system:masters config and must issue exactly one
+// non-long-running request with it, e.g. a large list. Shutdown is triggered as
+// soon as that request has been written to the server.
+func DrainServer(ctx context.Context, t *testing.T, server kcptestingserver.RunningServer, request func(ctx context.Context, cfg *rest.Config) error) {
+	t.Helper()
+
+	written := make(chan struct{})
+	var once sync.Once
+	cfg := rest.CopyConfig(server.RootShardSystemMasterBaseConfig(t))
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			trace := &httptrace.ClientTrace{
+				WroteRequest: func(httptrace.WroteRequestInfo) {
+					once.Do(func() { close(written) })
+				},
+			}
+			return rt.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+		})
+	})
+
+	requestErr := make(chan error, 1)
+	go func() {
+		requestErr <- request(ctx, cfg)
+	}()
+
+	select {
+	case <-written:
+	case err := <-requestErr:
+		require.NoError(t, err, "request failed before shutdown was triggered")
+		require.Fail(t, "request completed before shutdown was triggered")
+	case <-time.After(wait.ForeverTestTimeout):
+		require.Fail(t, "request was not sent to the server")
+	}
+
+	t.Logf("Request is in flight, draining server %s", server.Name())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		server.Stop()
+	}()
+
+	select {
+	case err := <-requestErr:
+		require.NoError(t, err, "in-flight request did not complete during the drain")
+	case <-time.After(wait.ForeverTestTimeout):
+		require.Fail(t, "in-flight request did not return during the drain")
+	}
+
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		select {
+		case <-stopped:
+			return server.Stopped(), "server did not report being stopped"
+		default:
+			return false, "server is still shutting down"
+		}
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "server %s did not shut down", server.Name())
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
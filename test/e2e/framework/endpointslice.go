@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	topologyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/topology/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+)
+
+// NewPartitionedEndpointSlice creates a Partition selecting the shards that
+// match shardSelector and an APIExportEndpointSlice of the given export
+// restricted to it, both named name in path. The slice only lists the virtual
+// workspace URLs of the shards in the partition that host bindings of the
+// export, see AssertEndpointSliceShards. Shards are selected by their labels,
+// the sharded test server labels every shard with its "region" and with
+// "shared=true". It waits for the slice to accept the partition and returns it.
+func NewPartitionedEndpointSlice(ctx context.Context, t *testing.T, client kcpclientset.ClusterInterface, path logicalcluster.Path, name string, export apisv1alpha1.ExportBindingReference, shardSelector *metav1.LabelSelector) *apisv1alpha1.APIExportEndpointSlice {
+	t.Helper()
+
+	t.Logf("Creating Partition %s|%s selecting shards %s", path, name, metav1.FormatLabelSelector(shardSelector))
+	_, err := client.Cluster(path).TopologyV1alpha1().Partitions().Create(ctx, &topologyv1alpha1.Partition{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: topologyv1alpha1.PartitionSpec{
+			Selector: shardSelector,
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Partition %s|%s", path, name)
+
+	t.Logf("Creating APIExportEndpointSlice %s|%s for APIExport %s|%s", path, name, export.Path, export.Name)
+	slices := client.Cluster(path).ApisV1alpha1().APIExportEndpointSlices()
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		_, err := slices.Create(ctx, &apisv1alpha1.APIExportEndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: apisv1alpha1.APIExportEndpointSliceSpec{
+				APIExport: export,
+				Partition: name,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return false, fmt.Sprintf("failed to create APIExportEndpointSlice: %v", err)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "APIExportEndpointSlice %s|%s could not be created", path, name)
+
+	var slice *apisv1alpha1.APIExportEndpointSlice
+	kcptestinghelpers.EventuallyCondition(t, func() (conditions.Getter, error) {
+		var err error
+		slice, err = slices.Get(ctx, name, metav1.GetOptions{})
+		return slice, err
+	}, kcptestinghelpers.Is(apisv1alpha1.PartitionValid), "APIExportEndpointSlice %s|%s did not accept Partition %s", path, name, name)
+	return slice
+}
+
+// AssertEndpointSliceShards waits until the APIExportEndpointSlice name in path
+// lists exactly one endpoint for each of the given shards, i.e. a URL below the
+// virtual workspace URL of the shard, and none for any other shard. This is
+// where clients of the slice are directed to.
+func AssertEndpointSliceShards(ctx context.Context, t *testing.T, client kcpclientset.ClusterInterface, path logicalcluster.Path, name string, shards []string) {
+	t.Helper()
+
+	shardList, err := client.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err, "failed to list shards")
+	virtualWorkspaceURLs := make(map[string]string, len(shardList.Items))
+	for _, shard := range shardList.Items {
+		virtualWorkspaceURLs[shard.Name] = strings.TrimSuffix(shard.Spec.VirtualWorkspaceURL, "/") + "/"
+	}
+	expected := append([]string(nil), shards...)
+	sort.Strings(expected)
+
+	t.Logf("Waiting for APIExportEndpointSlice %s|%s to list the endpoints of shards %v", path, name, expected)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		slice, err := client.Cluster(path).ApisV1alpha1().APIExportEndpointSlices().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Sprintf("failed to get APIExportEndpointSlice: %v", err)
+		}
+		var got []string
+		for _, endpoint := range slice.Status.APIExportEndpoints {
+			shard := ""
+			for shardName, prefix := range virtualWorkspaceURLs {
+				if strings.HasPrefix(endpoint.URL, prefix) {
+					shard = shardName
+					break
+				}
+			}
+			if shard == "" {
+				return false, fmt.Sprintf("endpoint %s belongs to no shard", endpoint.URL)
+			}
+			got = append(got, shard)
+		}
+		sort.Strings(got)
+		if strings.Join(got, ",") != strings.Join(expected, ",") {
+			return false, fmt.Sprintf("APIExportEndpointSlice lists the endpoints of shards %v", got)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "APIExportEndpointSlice %s|%s did not list the endpoints of shards %v", path, name, expected)
+}
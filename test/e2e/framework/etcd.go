@@ -0,0 +1,287 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/server/v3/embed"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+)
+
+// StartEtcd starts a single-member etcd in the test process, listening on
+// plain HTTP on localhost, and returns its client URL. It is stopped when the
+// test ends. Pass the URL to kcp with --etcd-servers to have several servers
+// share one etcd, e.g. in combination with kcptestingserver.WithEtcdPrefix.
+func StartEtcd(t *testing.T) string {
+	t.Helper()
+
+	clientPort, err := kcptestingserver.GetFreePort(t)
+	require.NoError(t, err, "failed to get free port for etcd clients")
+	peerPort, err := kcptestingserver.GetFreePort(t)
+	require.NoError(t, err, "failed to get free port for etcd peers")
+	clientURL := url.URL{Scheme: "http", Host: "localhost:" + clientPort}
+	peerURL := url.URL{Scheme: "http", Host: "localhost:" + peerPort}
+
+	cfg := embed.NewConfig()
+	cfg.Name = "e2e"
+	cfg.Dir = t.TempDir()
+	cfg.LogLevel = "error"
+	cfg.ListenClientUrls = []url.URL{clientURL}
+	cfg.AdvertiseClientUrls = []url.URL{clientURL}
+	cfg.ListenPeerUrls = []url.URL{peerURL}
+	cfg.AdvertisePeerUrls = []url.URL{peerURL}
+	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
+
+	t.Logf("Starting etcd on %s", clientURL.String())
+	etcd, err := embed.StartEtcd(cfg)
+	require.NoError(t, err, "failed to start etcd")
+	t.Cleanup(etcd.Close)
+
+	select {
+	case <-etcd.Server.ReadyNotify():
+	case err := <-etcd.Err():
+		require.NoError(t, err, "etcd failed")
+	case <-time.After(time.Minute):
+		require.Fail(t, "etcd did not become ready")
+	}
+
+	return clientURL.String()
+}
+
+// PausableEtcd is an etcd started with StartEtcd behind a TCP proxy, which
+// can stop forwarding traffic to simulate an unresponsive etcd. Pass URL to kcp
+// with --etcd-servers.
+type PausableEtcd struct {
+	URL string
+
+	upstream string
+	lock     sync.Mutex
+	// running is closed while traffic is forwarded.
+	running chan struct{}
+	conns   map[net.Conn]struct{}
+}
+
+// StartPausableEtcd starts an etcd like StartEtcd and a TCP proxy in front of
+// it. Both are stopped when the test ends.
+func StartPausableEtcd(t *testing.T) *PausableEtcd {
+	t.Helper()
+
+	upstream, err := url.Parse(StartEtcd(t))
+	require.NoError(t, err, "failed to parse etcd URL")
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err, "failed to listen for etcd proxy")
+	running := make(chan struct{})
+	close(running)
+	e := &PausableEtcd{
+		URL:      "http://" + listener.Addr().String(),
+		upstream: upstream.Host,
+		running:  running,
+		conns:    map[net.Conn]struct{}{},
+	}
+	t.Cleanup(func() {
+		listener.Close()
+		e.Resume()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go e.forward(conn)
+		}
+	}()
+
+	return e
+}
+
+func (e *PausableEtcd) forward(conn net.Conn) {
+	upstream, err := net.Dial("tcp", e.upstream)
+	if err != nil {
+		conn.Close()
+		return
+	}
+
+	e.lock.Lock()
+	e.conns[conn] = struct{}{}
+	e.conns[upstream] = struct{}{}
+	e.lock.Unlock()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		e.copy(upstream, conn)
+	}()
+	go func() {
+		defer wg.Done()
+		e.copy(conn, upstream)
+	}()
+	wg.Wait()
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	delete(e.conns, conn)
+	delete(e.conns, upstream)
+}
+
+// copy copies from src to dst, holding back data while paused, and closes both
+// when done.
+func (e *PausableEtcd) copy(dst, src net.Conn) {
+	defer dst.Close()
+	defer src.Close()
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			e.lock.Lock()
+			running := e.running
+			e.lock.Unlock()
+			<-running
+
+			if _, err := dst.Write(buf[:n]); err != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Pause stops forwarding traffic to and from etcd until Resume is called.
+// Connections stay open, so clients hang like with an unresponsive etcd.
+func (e *PausableEtcd) Pause() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	select {
+	case <-e.running:
+		e.running = make(chan struct{})
+	default:
+	}
+}
+
+// Resume forwards traffic again. All connections are closed, so that clients
+// reconnect and requests sent while paused never reach etcd.
+func (e *PausableEtcd) Resume() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	for conn := range e.conns {
+		conn.Close()
+	}
+	select {
+	case <-e.running:
+	default:
+		close(e.running)
+	}
+}
+
+// etcdOutageTimeout is the request timeout of writes while etcd is paused.
+const etcdOutageTimeout = 5 * time.Second
+
+// WithEtcdPaused pauses etcd and calls fn with a copy of cfg whose requests
+// time out after a few seconds. fn is expected to write to the server behind
+// cfg, which must use etcd as storage. WithEtcdPaused fails if fn does not fail
+// fast with a timeout or 503 Service Unavailable. It then resumes etcd and
+// calls fn again until it succeeds, i.e. the server recovered. fn must be
+// idempotent or use generated names, as a write can be retried.
+func WithEtcdPaused(ctx context.Context, t *testing.T, etcd *PausableEtcd, cfg *rest.Config, fn func(ctx context.Context, cfg *rest.Config) error) {
+	t.Helper()
+
+	cfg = rest.CopyConfig(cfg)
+	cfg.Timeout = etcdOutageTimeout
+
+	t.Logf("Pausing etcd at %s", etcd.URL)
+	etcd.Pause()
+	resumed := false
+	defer func() {
+		if !resumed {
+			etcd.Resume()
+		}
+	}()
+
+	start := time.Now()
+	err := fn(ctx, cfg)
+	elapsed := time.Since(start)
+	t.Logf("Write with paused etcd returned after %s: %v", elapsed, err)
+	require.Error(t, err, "write succeeded while etcd was paused")
+	require.True(t, isOutageError(err), "write with paused etcd did not fail with a timeout or 503, got: %v", err)
+	require.Less(t, elapsed, 3*etcdOutageTimeout, "write with paused etcd did not fail fast")
+
+	t.Logf("Resuming etcd at %s", etcd.URL)
+	etcd.Resume()
+	resumed = true
+
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		if err := fn(ctx, cfg); err != nil {
+			return false, err.Error()
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "server did not recover after etcd was resumed")
+}
+
+// isOutageError returns whether err is a timeout on either side or a 503.
+func isOutageError(err error) bool {
+	if apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err) || apierrors.IsServiceUnavailable(err) {
+		return true
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// AssertEtcdSizeStable fails if the etcd database of server grew by more than
+// maxGrowth bytes between the first sample taken at or after since and the
+// latest sample. The server must be started with
+// kcptestingserver.WithEtcdSizeSampling, and the test must have run for at least
+// two sampling intervals since then. Compaction must be enabled for space of
+// deleted and overwritten objects to be reused.
+func AssertEtcdSizeStable(t *testing.T, server kcptestingserver.RunningServer, since time.Time, maxGrowth int64) {
+	t.Helper()
+
+	var samples []kcptestingserver.EtcdSizeSample
+	for _, s := range kcptestingserver.EtcdSizeSamples(server) {
+		if !s.Time.Before(since) {
+			samples = append(samples, s)
+		}
+	}
+	require.GreaterOrEqual(t, len(samples), 2, "not enough etcd size samples of server %s since %s, is it started with WithEtcdSizeSampling?", server.Name(), since)
+
+	first, last := samples[0], samples[len(samples)-1]
+	growth := last.Bytes - first.Bytes
+	t.Logf("etcd of server %s grew by %d bytes from %d to %d bytes in %s", server.Name(), growth, first.Bytes, last.Bytes, last.Time.Sub(first.Time))
+	require.LessOrEqual(t, growth, maxGrowth, "etcd of server %s grew by more than %d bytes", server.Name(), maxGrowth)
+}
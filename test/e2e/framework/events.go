@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+)
+
+// WaitForEvent waits for a core/v1 Event with the given reason about
+// involvedObject in namespace of clusterPath and returns it. Only the kind and
+// name of involvedObject, and its UID if set, are matched.
+func WaitForEvent(ctx context.Context, t *testing.T, kubeClusterClient kcpkubernetesclientset.ClusterInterface, clusterPath logicalcluster.Path, namespace string, involvedObject corev1.ObjectReference, reason string) *corev1.Event {
+	t.Helper()
+
+	selector := fields.Set{
+		"involvedObject.kind": involvedObject.Kind,
+		"involvedObject.name": involvedObject.Name,
+		"reason":              reason,
+	}
+	if involvedObject.UID != "" {
+		selector["involvedObject.uid"] = string(involvedObject.UID)
+	}
+
+	var event *corev1.Event
+	t.Logf("Waiting for event %s about %s %s in %s|%s", reason, involvedObject.Kind, involvedObject.Name, clusterPath, namespace)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		events, err := kubeClusterClient.Cluster(clusterPath).CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector.AsSelector().String()})
+		if err != nil {
+			return false, fmt.Sprintf("failed to list events: %v", err)
+		}
+		if len(events.Items) == 0 {
+			return false, "no matching event"
+		}
+		event = &events.Items[0]
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "event %s about %s %s was not emitted", reason, involvedObject.Kind, involvedObject.Name)
+
+	return event
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+)
+
+// InjectStuckFinalizer adds finalizer, which no controller handles, to the
+// object of gvr namespace/name, so that deleting the object, or anything
+// deleting it in turn like a namespace or workspace deletion, is stuck until
+// the returned function removes the finalizer again. The finalizer is also
+// removed when the test ends. namespace is empty for cluster-scoped objects.
+// client must be scoped to the workspace of the object.
+func InjectStuckFinalizer(ctx context.Context, t *testing.T, client dynamic.Interface, gvr schema.GroupVersionResource, namespace, name, finalizer string) (release func()) {
+	t.Helper()
+
+	resource := client.Resource(gvr).Namespace(namespace)
+
+	t.Logf("Adding finalizer %s to %s %s", finalizer, gvr.Resource, name)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := resource.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if slices.Contains(obj.GetFinalizers(), finalizer) {
+			return nil
+		}
+		obj.SetFinalizers(append(obj.GetFinalizers(), finalizer))
+		_, err = resource.Update(ctx, obj, metav1.UpdateOptions{})
+		return err
+	})
+	require.NoError(t, err, "failed to add finalizer %s to %s %s", finalizer, gvr.Resource, name)
+
+	var once sync.Once
+	release = func() {
+		once.Do(func() {
+			t.Logf("Removing finalizer %s from %s %s", finalizer, gvr.Resource, name)
+			err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				obj, err := resource.Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				finalizers := slices.DeleteFunc(obj.GetFinalizers(), func(f string) bool { return f == finalizer })
+				if len(finalizers) == len(obj.GetFinalizers()) {
+					return nil
+				}
+				obj.SetFinalizers(finalizers)
+				_, err = resource.Update(ctx, obj, metav1.UpdateOptions{})
+				return err
+			})
+			if apierrors.IsNotFound(err) {
+				return
+			}
+			require.NoError(t, err, "failed to remove finalizer %s from %s %s", finalizer, gvr.Resource, name)
+		})
+	}
+	t.Cleanup(release)
+
+	return release
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/rest"
+)
+
+// AssertRequestQueued verifies via the priority-and-fairness metrics of the
+// server behind cfg that requests have been classified into the given flow
+// schema, and returns how many of them were dispatched and rejected. cfg must
+// be allowed to read /metrics of a shard.
+func AssertRequestQueued(ctx context.Context, t *testing.T, cfg *rest.Config, flowSchema string) (dispatched, rejected float64) {
+	t.Helper()
+
+	metrics := GetMetricsSnapshot(ctx, t, cfg)
+	labels := map[string]string{"flow_schema": flowSchema}
+	dispatched = metrics.Sum("apiserver_flowcontrol_dispatched_requests_total", labels)
+	rejected = metrics.Sum("apiserver_flowcontrol_rejected_requests_total", labels)
+	require.Positive(t, dispatched+rejected, "no requests were classified into flow schema %q", flowSchema)
+
+	t.Logf("Flow schema %q: %v requests dispatched, %v rejected", flowSchema, dispatched, rejected)
+	return dispatched, rejected
+}
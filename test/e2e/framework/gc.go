@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/utils/ptr"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+)
+
+// GCObject identifies an object for DeleteAndWaitForegroundGC. Namespace is
+// empty for cluster-scoped objects.
+type GCObject struct {
+	GVR       schema.GroupVersionResource
+	Namespace string
+	Name      string
+}
+
+func (o GCObject) String() string {
+	if o.Namespace == "" {
+		return fmt.Sprintf("%s %s", o.GVR.GroupResource(), o.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", o.GVR.GroupResource(), o.Namespace, o.Name)
+}
+
+// DeleteAndWaitForegroundGC deletes owner with foreground propagation and waits
+// for owner and all of its dependents to be gone. It fails if the owner is
+// removed while any of the dependents still exists, which foreground deletion
+// must never do. The dependents must reference the owner with
+// blockOwnerDeletion set, otherwise the ordering is not guaranteed. client must
+// be scoped to the workspace of the objects.
+func DeleteAndWaitForegroundGC(ctx context.Context, t *testing.T, client dynamic.Interface, owner GCObject, dependents ...GCObject) {
+	t.Helper()
+
+	get := func(o GCObject) (*unstructured.Unstructured, error) {
+		return client.Resource(o.GVR).Namespace(o.Namespace).Get(ctx, o.Name, metav1.GetOptions{})
+	}
+
+	t.Logf("Deleting %s with foreground propagation", owner)
+	err := client.Resource(owner.GVR).Namespace(owner.Namespace).Delete(ctx, owner.Name, metav1.DeleteOptions{
+		PropagationPolicy: ptr.To(metav1.DeletePropagationForeground),
+	})
+	require.NoError(t, err, "failed to delete %s", owner)
+
+	t.Logf("Waiting for %s and %d dependents to be garbage collected", owner, len(dependents))
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		// The owner is checked first: once it is gone, dependents that are
+		// still found were there when the owner was removed.
+		obj, err := get(owner)
+		ownerGone := apierrors.IsNotFound(err)
+		if err != nil && !ownerGone {
+			return false, fmt.Sprintf("failed to get %s: %v", owner, err)
+		}
+		if !ownerGone {
+			require.NotNil(t, obj.GetDeletionTimestamp(), "%s is not being deleted", owner)
+			require.True(t, slices.Contains(obj.GetFinalizers(), metav1.FinalizerDeleteDependents), "%s is not deleted in the foreground, finalizers are %v", owner, obj.GetFinalizers())
+		}
+
+		var remaining []string
+		for _, dependent := range dependents {
+			_, err := get(dependent)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return false, fmt.Sprintf("failed to get %s: %v", dependent, err)
+			}
+			remaining = append(remaining, dependent.String())
+		}
+		require.False(t, ownerGone && len(remaining) > 0, "%s was removed before its dependents %v", owner, remaining)
+
+		if !ownerGone {
+			return false, fmt.Sprintf("%s still exists, dependents remaining: %v", owner, remaining)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "%s was not garbage collected in the foreground", owner)
+}
+
+// AssertCrossWorkspaceOwnerRefRejected creates a ConfigMap in the default
+// namespace of pathB and a ConfigMap in pathA owned by it, and verifies that
+// the owner reference is not honored across the workspaces: either the
+// dependent is rejected on creation, or the garbage collector, which only
+// resolves owners in pathA, treats the owner as missing and deletes the
+// dependent. The owner must not be affected. If pathA and pathB are the same,
+// the dependent must be created and be garbage collected only once its owner
+// is deleted.
+func AssertCrossWorkspaceOwnerRefRejected(ctx context.Context, t *testing.T, client kcpkubernetesclientset.ClusterInterface, pathA, pathB logicalcluster.Path) {
+	t.Helper()
+
+	t.Logf("Creating owner ConfigMap in %s", pathB)
+	owner, err := client.Cluster(pathB).CoreV1().ConfigMaps("default").Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "owner-"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create owner ConfigMap in %s", pathB)
+
+	t.Logf("Creating ConfigMap in %s owned by ConfigMap %s|default/%s", pathA, pathB, owner.Name)
+	dependents := client.Cluster(pathA).CoreV1().ConfigMaps("default")
+	dependent, err := dependents.Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "dependent-",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Name:       owner.Name,
+				UID:        owner.UID,
+			}},
+		},
+	}, metav1.CreateOptions{})
+
+	if pathA == pathB {
+		require.NoError(t, err, "failed to create ConfigMap owned by a ConfigMap of the same workspace")
+		_, err := dependents.Get(ctx, dependent.Name, metav1.GetOptions{})
+		require.NoError(t, err, "ConfigMap owned by a ConfigMap of the same workspace is gone before its owner")
+
+		t.Logf("Deleting owner ConfigMap %s|default/%s", pathB, owner.Name)
+		err = client.Cluster(pathB).CoreV1().ConfigMaps("default").Delete(ctx, owner.Name, metav1.DeleteOptions{})
+		require.NoError(t, err, "failed to delete owner ConfigMap")
+		waitForConfigMapDeletion(ctx, t, client, pathA, dependent.Name)
+		return
+	}
+
+	if err != nil {
+		t.Logf("Creation was rejected: %v", err)
+		require.True(t, apierrors.IsInvalid(err) || apierrors.IsForbidden(err), "expected the cross-workspace owner reference to be rejected as invalid or forbidden, got: %v", err)
+	} else {
+		t.Logf("Waiting for the garbage collector to delete ConfigMap %s|default/%s with the unresolvable owner", pathA, dependent.Name)
+		waitForConfigMapDeletion(ctx, t, client, pathA, dependent.Name)
+	}
+
+	_, err = client.Cluster(pathB).CoreV1().ConfigMaps("default").Get(ctx, owner.Name, metav1.GetOptions{})
+	require.NoError(t, err, "owner ConfigMap %s|default/%s was affected by the cross-workspace owner reference", pathB, owner.Name)
+}
+
+func waitForConfigMapDeletion(ctx context.Context, t *testing.T, client kcpkubernetesclientset.ClusterInterface, path logicalcluster.Path, name string) {
+	t.Helper()
+
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		_, err := client.Cluster(path).CoreV1().ConfigMaps("default").Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, ""
+		}
+		if err != nil {
+			return false, fmt.Sprintf("failed to get ConfigMap: %v", err)
+		}
+		return false, "ConfigMap still exists"
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "ConfigMap %s|default/%s was not garbage collected", path, name)
+}
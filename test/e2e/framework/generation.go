@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+)
+
+// ObservedGenerationGetter is implemented by objects that expose the
+// generation last processed by their controller.
+type ObservedGenerationGetter interface {
+	GetObservedGeneration() int64
+}
+
+// WaitForObservedGeneration polls getFn until the returned object's
+// status.observedGeneration equals its metadata.generation, i.e. until its
+// controller has processed the latest spec. Objects implementing
+// ObservedGenerationGetter are asked directly, all others are read through
+// their unstructured status.observedGeneration field. The last object is
+// returned.
+func WaitForObservedGeneration[T runtime.Object](ctx context.Context, t *testing.T, getFn func(ctx context.Context) (T, error)) T {
+	t.Helper()
+
+	var obj T
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		var err error
+		obj, err = getFn(ctx)
+		if err != nil {
+			return false, fmt.Sprintf("failed to get object: %v", err)
+		}
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return false, fmt.Sprintf("failed to access object metadata: %v", err)
+		}
+		observed, err := observedGeneration(obj)
+		if err != nil {
+			return false, err.Error()
+		}
+		if observed != accessor.GetGeneration() {
+			return false, fmt.Sprintf("observed generation %d does not match generation %d", observed, accessor.GetGeneration())
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "observed generation did not catch up with generation")
+
+	return obj
+}
+
+func observedGeneration(obj runtime.Object) (int64, error) {
+	if getter, ok := obj.(ObservedGenerationGetter); ok {
+		return getter.GetObservedGeneration(), nil
+	}
+
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return 0, fmt.Errorf("failed to convert object to unstructured: %w", err)
+		}
+		u = &unstructured.Unstructured{Object: content}
+	}
+	observed, _, err := unstructured.NestedInt64(u.Object, "status", "observedGeneration")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read status.observedGeneration: %w", err)
+	}
+	return observed, nil
+}
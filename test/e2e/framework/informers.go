@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// InformerFactory is implemented by the shared informer factories generated by
+// informer-gen, both the upstream and the cluster-aware ones.
+type InformerFactory interface {
+	Start(stopCh <-chan struct{})
+	WaitForCacheSync(stopCh <-chan struct{}) map[reflect.Type]bool
+}
+
+// WaitForInformerSync starts factory until ctx is done and waits for all of its
+// informers to sync. Only informers requested from the factory before are
+// started. It fails with the types of the informers that did not sync in time.
+func WaitForInformerSync(ctx context.Context, t *testing.T, factory InformerFactory) {
+	t.Helper()
+
+	factory.Start(ctx.Done())
+
+	syncCtx, cancel := context.WithTimeout(ctx, wait.ForeverTestTimeout)
+	defer cancel()
+	synced := factory.WaitForCacheSync(syncCtx.Done())
+
+	var unsynced []string
+	for typ, ok := range synced {
+		if !ok {
+			unsynced = append(unsynced, typ.String())
+		}
+	}
+	sort.Strings(unsynced)
+	require.Empty(t, unsynced, "informers did not sync")
+	t.Logf("%d informers synced", len(synced))
+}
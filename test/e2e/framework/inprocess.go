@@ -18,11 +18,18 @@ package framework
 
 import (
 	"context"
+	goflag "flag"
+	"fmt"
+	"sync"
 
 	"github.com/spf13/pflag"
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/component-base/cli/flag"
+	"k8s.io/component-base/featuregate"
+	"k8s.io/klog/v2"
+	"k8s.io/klog/v2/textlogger"
 
 	"github.com/kcp-dev/embeddedetcd"
 
@@ -31,11 +38,160 @@ import (
 	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
 )
 
+// explicitFeatureGates returns the feature gates explicitly set in the process
+// wide feature gate, e.g. through --feature-gates of an in-process server.
+func explicitFeatureGates() map[string]bool {
+	gates := map[string]bool{}
+	for name := range utilfeature.DefaultMutableFeatureGate.GetAll() {
+		if utilfeature.DefaultMutableFeatureGate.ExplicitlySet(name) {
+			gates[string(name)] = utilfeature.DefaultMutableFeatureGate.Enabled(name)
+		}
+	}
+	return gates
+}
+
+// restoreFeatureGates resets all explicitly set feature gates to the given
+// snapshot of explicitFeatureGates.
+func restoreFeatureGates(snapshot map[string]bool) error {
+	for name := range explicitFeatureGates() {
+		if _, ok := snapshot[name]; ok {
+			continue
+		}
+		if err := utilfeature.DefaultMutableFeatureGate.ResetFeatureValueToDefault(featuregate.Feature(name)); err != nil {
+			return fmt.Errorf("failed to reset feature gate %s: %w", name, err)
+		}
+	}
+	if len(snapshot) == 0 {
+		return nil
+	}
+	return utilfeature.DefaultMutableFeatureGate.SetFromMap(snapshot)
+}
+
+// klogFlags returns a flag set bound to the process wide klog configuration,
+// e.g. -v and -vmodule.
+func klogFlags() *goflag.FlagSet {
+	fs := goflag.NewFlagSet("klog", goflag.ContinueOnError)
+	klog.InitFlags(fs)
+	return fs
+}
+
+// globalState is the process wide state mutated by in-process servers.
+type globalState struct {
+	featureGates map[string]bool
+	klogFlags    map[string]string
+}
+
+// captureGlobalState snapshots the explicitly set feature gates and the klog
+// configuration.
+func captureGlobalState() *globalState {
+	s := &globalState{
+		featureGates: explicitFeatureGates(),
+		klogFlags:    map[string]string{},
+	}
+	klogFlags().VisitAll(func(f *goflag.Flag) {
+		s.klogFlags[f.Name] = f.Value.String()
+	})
+	return s
+}
+
+// restore resets the feature gates and the klog configuration to the snapshot.
+func (s *globalState) restore() error {
+	errs := []error{restoreFeatureGates(s.featureGates)}
+	fs := klogFlags()
+	for name, value := range s.klogFlags {
+		if fs.Lookup(name).Value.String() == value {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			errs = append(errs, fmt.Errorf("failed to reset klog flag %s: %w", name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+var (
+	inProcessLock sync.Mutex
+	// inProcessServers is the number of in-process servers started and not
+	// stopped yet.
+	inProcessServers int
+	// inProcessState is the global state before the first of the running
+	// in-process servers started.
+	inProcessState *globalState
+)
+
+// startedInProcess records the start of an in-process server, and snapshots
+// the global state if no other in-process server is running.
+func startedInProcess() {
+	inProcessLock.Lock()
+	defer inProcessLock.Unlock()
+
+	if inProcessServers == 0 {
+		inProcessState = captureGlobalState()
+	}
+	inProcessServers++
+}
+
+// stoppedInProcess records the stop of an in-process server, and resets the
+// global state once no other in-process server is running anymore.
+func stoppedInProcess(t kcptestingserver.TestingT) {
+	t.Helper()
+
+	inProcessLock.Lock()
+	inProcessServers--
+	running := inProcessServers
+	inProcessLock.Unlock()
+
+	if running == 0 {
+		ResetGlobalState(t)
+	}
+}
+
+// ResetGlobalState restores the process wide state mutated by in-process
+// servers, i.e. feature gates set through --feature-gates and the klog
+// configuration, to what it was before the in-process servers started, and
+// flushes buffered klog output. The in-process runner calls it once the last
+// running in-process server stopped. Call it in cleanup of tests that mutate
+// this state themselves. It must not be used while in-process servers are
+// running.
+func ResetGlobalState(t kcptestingserver.TestingT) {
+	t.Helper()
+
+	klog.Flush()
+
+	inProcessLock.Lock()
+	defer inProcessLock.Unlock()
+
+	if inProcessServers > 0 {
+		t.Errorf("cannot reset global state while %d in-process servers are running", inProcessServers)
+		return
+	}
+	if inProcessState == nil {
+		return
+	}
+	if err := inProcessState.restore(); err != nil {
+		t.Errorf("failed to reset global state after in-process kcp: %v", err)
+	}
+}
+
 func init() {
 	kcptestingserver.ContextRunInProcessFunc = func(ctx context.Context, t kcptestingserver.TestingT, cfg kcptestingserver.Config) (<-chan struct{}, error) {
 		ctx, cancel := context.WithCancel(ctx)
 		t.Cleanup(cancel)
 
+		// --feature-gates is parsed into the process wide feature gate. Reset the global
+		// state once the last running in-process server stopped, so the gates of this
+		// server do not leak into later ones, without changing them under servers still
+		// running.
+		startedInProcess()
+		var stopCh chan struct{}
+		t.Cleanup(func() {
+			cancel()
+			if stopCh != nil {
+				<-stopCh
+			}
+			stoppedInProcess(t)
+		})
+
 		serverOptions := kcpoptions.NewOptions(cfg.DataDir)
 		fss := flag.NamedFlagSets{}
 		serverOptions.AddFlags(&fss)
@@ -47,6 +203,17 @@ func init() {
 			return nil, err
 		}
 
+		if cfg.LogWriter != nil {
+			// log through a per-server contextual logger instead of the global klog output,
+			// so concurrently running in-process servers do not end up in each other's logs.
+			logger := textlogger.NewLogger(textlogger.NewConfig(
+				textlogger.Output(cfg.LogWriter),
+				textlogger.Verbosity(int(serverOptions.Server.GenericControlPlane.Logs.Verbosity)),
+			))
+			ctx = klog.NewContext(ctx, logger)
+			logger.Info("starting in-process kcp", "dataDir", cfg.DataDir)
+		}
+
 		completed, err := serverOptions.Complete(ctx)
 		if err != nil {
 			return nil, err
@@ -55,6 +222,9 @@ func init() {
 			return nil, utilerrors.NewAggregate(errs)
 		}
 
+		// add feature enablement metrics
+		utilfeature.DefaultMutableFeatureGate.AddMetrics()
+
 		config, err := server.NewConfig(ctx, completed.Server)
 		if err != nil {
 			return nil, err
@@ -72,11 +242,11 @@ func init() {
 			}
 		}
 
-		stopCh := make(chan struct{})
 		s, err := server.NewServer(completedConfig)
 		if err != nil {
 			return nil, err
 		}
+		stopCh = make(chan struct{})
 		go func() {
 			defer close(stopCh)
 			if err := s.Run(ctx); err != nil && ctx.Err() == nil {
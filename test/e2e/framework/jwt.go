@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+	"sigs.k8s.io/yaml"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiserverv1beta1 "k8s.io/apiserver/pkg/apis/apiserver/v1beta1"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+)
+
+// JWTIssuer is a minimal OpenID Connect provider serving discovery and the
+// signing keys of the JWTs it issues over TLS.
+type JWTIssuer struct {
+	// URL is the issuer URL, the iss claim of the issued tokens.
+	URL string
+	// CAData is the PEM encoded CA of the serving certificate.
+	CAData []byte
+
+	signer jose.Signer
+}
+
+// StartJWTIssuer starts a JWTIssuer that is stopped when the test ends.
+func StartJWTIssuer(t *testing.T) *JWTIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "failed to generate signing key")
+	const keyID = "e2e"
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, (&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", keyID))
+	require.NoError(t, err, "failed to construct signer")
+
+	issuer := &JWTIssuer{signer: signer}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                issuer.URL,
+			"jwks_uri":                              issuer.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+			Key:       &key.PublicKey,
+			KeyID:     keyID,
+			Algorithm: string(jose.RS256),
+			Use:       "sig",
+		}}})
+	})
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	issuer.URL = server.URL
+	issuer.CAData = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	return issuer
+}
+
+// WriteAuthenticationConfig writes an AuthenticationConfiguration trusting the
+// issuer for the given audience, for kcptestingserver.WithAuthenticationConfig.
+// The sub claim is mapped to the username with usernamePrefix prepended and
+// the groups claim to the groups. It returns the path of the file.
+func (i *JWTIssuer) WriteAuthenticationConfig(t *testing.T, audience, usernamePrefix string) string {
+	t.Helper()
+
+	config := apiserverv1beta1.AuthenticationConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiserverv1beta1.SchemeGroupVersion.String(),
+			Kind:       "AuthenticationConfiguration",
+		},
+		JWT: []apiserverv1beta1.JWTAuthenticator{{
+			Issuer: apiserverv1beta1.Issuer{
+				URL:                  i.URL,
+				CertificateAuthority: string(i.CAData),
+				Audiences:            []string{audience},
+			},
+			ClaimMappings: apiserverv1beta1.ClaimMappings{
+				Username: apiserverv1beta1.PrefixedClaimOrExpression{Claim: "sub", Prefix: ptr.To(usernamePrefix)},
+				Groups:   apiserverv1beta1.PrefixedClaimOrExpression{Claim: "groups", Prefix: ptr.To("")},
+			},
+		}},
+	}
+	data, err := yaml.Marshal(config)
+	require.NoError(t, err, "failed to marshal authentication config")
+	path := filepath.Join(t.TempDir(), "authentication-config.yaml")
+	require.NoError(t, os.WriteFile(path, data, 0600), "failed to write authentication config")
+	return path
+}
+
+// Token returns a JWT of the issuer for the given audience and subject, valid
+// for an hour.
+func (i *JWTIssuer) Token(t *testing.T, audience, subject string, groups ...string) string {
+	t.Helper()
+
+	now := time.Now()
+	token, err := jwt.Signed(i.signer).Claims(jwt.Claims{
+		Issuer:   i.URL,
+		Subject:  subject,
+		Audience: jwt.Audience{audience},
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+	}).Claims(map[string]interface{}{
+		"groups": groups,
+	}).CompactSerialize()
+	require.NoError(t, err, "failed to sign token")
+	return token
+}
+
+// JWTConfig returns a copy of cfg authenticating with a JWT of the issuer for
+// the given audience, subject and groups.
+func (i *JWTIssuer) JWTConfig(t *testing.T, cfg *rest.Config, audience, subject string, groups ...string) *rest.Config {
+	t.Helper()
+
+	return ConfigWithToken(i.Token(t, audience, subject, groups...), cfg)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+)
+
+// AssertSingleLeader waits for the Lease leaseNamespace/leaseName to be held
+// and returns its holder. Lease-based leader election allows at most one holder
+// at a time, so it additionally verifies that the holder stays the same while
+// it renews the Lease, i.e. that no other candidate takes over an actively
+// renewed Lease. Windows without a holder, e.g. during a failover, are
+// tolerated while waiting.
+func AssertSingleLeader(ctx context.Context, t *testing.T, client kubernetes.Interface, leaseNamespace, leaseName string) string {
+	t.Helper()
+
+	t.Logf("Waiting for Lease %s/%s to be held", leaseNamespace, leaseName)
+	lease := waitForLeaseHolder(ctx, t, client, leaseNamespace, leaseName, func(*coordinationv1.Lease) bool { return true })
+	holder := ptr.Deref(lease.Spec.HolderIdentity, "")
+	renewed := lease.Spec.RenewTime
+
+	t.Logf("Waiting for holder %s to renew Lease %s/%s", holder, leaseNamespace, leaseName)
+	var current string
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		lease, err := client.CoordinationV1().Leases(leaseNamespace).Get(ctx, leaseName, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Sprintf("failed to get Lease: %v", err)
+		}
+		if current = ptr.Deref(lease.Spec.HolderIdentity, ""); current != holder {
+			return true, ""
+		}
+		if lease.Spec.RenewTime == nil || (renewed != nil && !lease.Spec.RenewTime.After(renewed.Time)) {
+			return false, fmt.Sprintf("Lease was not renewed since %v", renewed)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "Lease %s/%s was not renewed by %s", leaseNamespace, leaseName, holder)
+	require.Equal(t, holder, current, "Lease %s/%s changed holder while being renewed", leaseNamespace, leaseName)
+
+	return holder
+}
+
+// ForceLeaderFailover deletes the Lease leaseNamespace/leaseName and waits for
+// a candidate to acquire the recreated Lease. It returns the new holder. With a
+// single candidate the previous leader acquires the Lease again.
+func ForceLeaderFailover(ctx context.Context, t *testing.T, client kubernetes.Interface, leaseNamespace, leaseName string) string {
+	t.Helper()
+
+	lease, err := client.CoordinationV1().Leases(leaseNamespace).Get(ctx, leaseName, metav1.GetOptions{})
+	require.NoError(t, err, "failed to get Lease %s/%s", leaseNamespace, leaseName)
+	t.Logf("Deleting Lease %s/%s held by %s", leaseNamespace, leaseName, ptr.Deref(lease.Spec.HolderIdentity, ""))
+	err = client.CoordinationV1().Leases(leaseNamespace).Delete(ctx, leaseName, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &lease.UID},
+	})
+	require.NoError(t, err, "failed to delete Lease %s/%s", leaseNamespace, leaseName)
+
+	t.Logf("Waiting for Lease %s/%s to be acquired again", leaseNamespace, leaseName)
+	oldUID := lease.UID
+	lease = waitForLeaseHolder(ctx, t, client, leaseNamespace, leaseName, func(lease *coordinationv1.Lease) bool {
+		return lease.UID != oldUID
+	})
+	holder := ptr.Deref(lease.Spec.HolderIdentity, "")
+	t.Logf("Lease %s/%s acquired by %s", leaseNamespace, leaseName, holder)
+	return holder
+}
+
+func waitForLeaseHolder(ctx context.Context, t *testing.T, client kubernetes.Interface, leaseNamespace, leaseName string, accept func(*coordinationv1.Lease) bool) *coordinationv1.Lease {
+	t.Helper()
+
+	var lease *coordinationv1.Lease
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		var err error
+		lease, err = client.CoordinationV1().Leases(leaseNamespace).Get(ctx, leaseName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return false, "Lease does not exist"
+		}
+		if err != nil {
+			return false, fmt.Sprintf("failed to get Lease: %v", err)
+		}
+		if !accept(lease) {
+			return false, "Lease was not recreated yet"
+		}
+		if ptr.Deref(lease.Spec.HolderIdentity, "") == "" {
+			return false, "Lease has no holder"
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "Lease %s/%s is not held", leaseNamespace, leaseName)
+	return lease
+}
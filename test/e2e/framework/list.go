@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// ListAllPages lists gvr with the given page size, following continue tokens
+// until the list is exhausted, and returns all items and the number of pages.
+// client must be scoped to a single logical cluster. It fails if an object is
+// returned more than once, or if the pages do not add up to the same objects
+// as a single unpaginated list.
+func ListAllPages(ctx context.Context, t *testing.T, client dynamic.Interface, gvr schema.GroupVersionResource, pageSize int64) ([]unstructured.Unstructured, int) {
+	t.Helper()
+
+	require.Greater(t, pageSize, int64(0), "page size must be positive")
+
+	var (
+		items []unstructured.Unstructured
+		pages int
+		seen  = map[types.UID]string{}
+		opts  = metav1.ListOptions{Limit: pageSize}
+	)
+	for {
+		list, err := client.Resource(gvr).List(ctx, opts)
+		require.NoError(t, err, "failed to list page %d of %s", pages+1, gvr.Resource)
+		pages++
+
+		require.LessOrEqual(t, int64(len(list.Items)), pageSize, "page %d of %s exceeds the limit", pages, gvr.Resource)
+		for _, item := range list.Items {
+			key := item.GetNamespace() + "/" + item.GetName()
+			if previous, found := seen[item.GetUID()]; found {
+				require.Failf(t, "duplicate object", "%s %s listed twice, first as %s", gvr.Resource, key, previous)
+			}
+			seen[item.GetUID()] = key
+		}
+		items = append(items, list.Items...)
+
+		if list.GetContinue() == "" {
+			break
+		}
+		require.NotEmpty(t, list.Items, "page %d of %s is empty but has a continue token", pages, gvr.Resource)
+		opts.Continue = list.GetContinue()
+	}
+
+	full, err := client.Resource(gvr).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err, "failed to list %s", gvr.Resource)
+	expected := make(map[types.UID]string, len(full.Items))
+	for _, item := range full.Items {
+		expected[item.GetUID()] = item.GetNamespace() + "/" + item.GetName()
+	}
+	require.Equal(t, expected, seen, "paginated list of %s does not match the full list", gvr.Resource)
+
+	return items, pages
+}
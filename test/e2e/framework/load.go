@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// LoadSpec describes the load generated by LoadTest.
+type LoadSpec struct {
+	// Rate is the target number of calls per second.
+	Rate float64
+	// Duration is how long load is generated for.
+	Duration time.Duration
+	// Concurrency is the maximum number of calls in flight at any time.
+	Concurrency int
+}
+
+// LoadResult summarizes a LoadTest run.
+type LoadResult struct {
+	Requests   int
+	Errors     int
+	Duration   time.Duration
+	Throughput float64
+	P50        time.Duration
+	P90        time.Duration
+	P99        time.Duration
+}
+
+// ErrorRate returns the fraction of calls that failed.
+func (r LoadResult) ErrorRate() float64 {
+	if r.Requests == 0 {
+		return 0
+	}
+	return float64(r.Errors) / float64(r.Requests)
+}
+
+// LoadTest calls fn at the rate given by spec for spec.Duration, with at most
+// spec.Concurrency calls in flight, and reports throughput, latency percentiles
+// and error rate. fn is passed the sequence number of the call. Ticks are
+// dropped if all workers are busy, so the achieved rate can be lower than the
+// target rate.
+func LoadTest(ctx context.Context, t *testing.T, fn func(ctx context.Context, i int) error, spec LoadSpec) LoadResult {
+	t.Helper()
+
+	require.Greater(t, spec.Rate, 0.0, "load rate must be positive")
+	require.Greater(t, spec.Duration, time.Duration(0), "load duration must be positive")
+	require.Greater(t, spec.Concurrency, 0, "load concurrency must be positive")
+
+	ctx, cancel := context.WithTimeout(ctx, spec.Duration)
+	defer cancel()
+
+	var (
+		lock      sync.Mutex
+		latencies []time.Duration
+		errs      int
+	)
+
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for range spec.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				start := time.Now()
+				err := fn(ctx, i)
+				latency := time.Since(start)
+
+				lock.Lock()
+				// calls cut off by the end of the run are not counted
+				if ctx.Err() == nil || err == nil {
+					latencies = append(latencies, latency)
+					if err != nil {
+						errs++
+					}
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+
+	start := time.Now()
+	// rates above one call per nanosecond would truncate to a zero interval
+	interval := max(time.Duration(float64(time.Second)/spec.Rate), time.Nanosecond)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+loop:
+	for i := 0; ; i++ {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-ticker.C:
+			select {
+			case work <- i:
+			default: // all workers are busy, drop the tick
+			}
+		}
+	}
+	close(work)
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	result := LoadResult{
+		Requests:   len(latencies),
+		Errors:     errs,
+		Duration:   elapsed,
+		Throughput: float64(len(latencies)) / elapsed.Seconds(),
+		P50:        percentile(latencies, 0.50),
+		P90:        percentile(latencies, 0.90),
+		P99:        percentile(latencies, 0.99),
+	}
+	t.Logf("Load test finished: %d requests in %s (%.1f req/s), error rate %.2f%%, latency p50=%s p90=%s p99=%s",
+		result.Requests, result.Duration, result.Throughput, 100*result.ErrorRate(), result.P50, result.P90, result.P99)
+
+	return result
+}
+
+// percentile returns the p-th percentile of the sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(float64(len(sorted)-1) * p)
+	return sorted[idx]
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+)
+
+// MatrixCase is a server configuration to run the body of a Matrix test
+// against.
+type MatrixCase struct {
+	// Name is the name of the subtest.
+	Name string
+	// Options configure the server of the case.
+	Options []kcptestingserver.Option
+}
+
+// MatrixClients are the server of a Matrix case and clients for it.
+type MatrixClients struct {
+	Server               kcptestingserver.RunningServer
+	KcpClusterClient     kcpclientset.ClusterInterface
+	KubeClusterClient    kcpkubernetesclientset.ClusterInterface
+	DynamicClusterClient kcpdynamic.ClusterInterface
+}
+
+// Matrix runs body as a subtest per case, each against a fresh private server
+// configured with the options of the case, e.g. with different storage media
+// types or feature gates. The servers write their artifacts to the artifact
+// directories of the subtests. Cases run one after another, unless body calls
+// t.Parallel.
+func Matrix(t *testing.T, cases []MatrixCase, body func(t *testing.T, clients MatrixClients)) {
+	t.Helper()
+
+	require.NotEmpty(t, cases, "matrix without cases")
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			server := kcptesting.PrivateKcpServer(t, c.Options...)
+
+			cfg := server.BaseConfig(t)
+			kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
+			require.NoError(t, err, "failed to construct kcp client for server")
+			kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+			require.NoError(t, err, "failed to construct kube client for server")
+			dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+			require.NoError(t, err, "failed to construct dynamic client for server")
+
+			body(t, MatrixClients{
+				Server:               server,
+				KcpClusterClient:     kcpClusterClient,
+				KubeClusterClient:    kubeClusterClient,
+				DynamicClusterClient: dynamicClusterClient,
+			})
+		})
+	}
+}
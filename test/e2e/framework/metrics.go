@@ -0,0 +1,209 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubernetesclientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/component-base/metrics/testutil"
+
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+)
+
+// MetricsSnapshot is a parsed scrape of the /metrics endpoint of a server,
+// keyed by metric name.
+type MetricsSnapshot testutil.Metrics
+
+// GetMetricsSnapshot scrapes and parses /metrics of the server behind cfg.
+// cfg must point to a shard and be allowed to read metrics, e.g. a
+// RootShardSystemMasterBaseConfig.
+func GetMetricsSnapshot(ctx context.Context, t *testing.T, cfg *rest.Config) MetricsSnapshot {
+	t.Helper()
+
+	metrics, err := scrapeMetrics(ctx, cfg)
+	require.NoError(t, err)
+	return metrics
+}
+
+// WaitForMetric waits until /metrics of the server behind cfg exposes at least
+// one sample of the named metric family and returns the snapshot it was found
+// in. Use it for metrics that only appear once a controller did some work, e.g.
+// workqueue_adds_total. cfg must be allowed to read metrics, e.g. a
+// RootShardSystemMasterBaseConfig.
+func WaitForMetric(ctx context.Context, t *testing.T, cfg *rest.Config, metricName string) MetricsSnapshot {
+	t.Helper()
+
+	var metrics MetricsSnapshot
+	t.Logf("Waiting for metric %s on %s", metricName, cfg.Host)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		var err error
+		metrics, err = scrapeMetrics(ctx, cfg)
+		if err != nil {
+			return false, err.Error()
+		}
+		return len(metrics[metricName]) > 0, fmt.Sprintf("metric %s not found", metricName)
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "metric %s did not appear on %s", metricName, cfg.Host)
+	return metrics
+}
+
+func scrapeMetrics(ctx context.Context, cfg *rest.Config) (MetricsSnapshot, error) {
+	client, err := kubernetesclientset.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct client for metrics: %w", err)
+	}
+
+	raw, err := client.RESTClient().Get().AbsPath("/metrics").DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metrics from %s: %w", cfg.Host, err)
+	}
+
+	metrics := testutil.NewMetrics()
+	if err := testutil.ParseMetrics(string(raw), &metrics); err != nil {
+		return nil, fmt.Errorf("failed to parse metrics from %s: %w", cfg.Host, err)
+	}
+	return MetricsSnapshot(metrics), nil
+}
+
+// Samples returns the samples of the named metric whose labels match all of
+// the given label values.
+func (s MetricsSnapshot) Samples(name string, labels map[string]string) model.Samples {
+	var matching model.Samples
+	for _, sample := range s[name] {
+		if sampleMatches(sample, labels) {
+			matching = append(matching, sample)
+		}
+	}
+	return matching
+}
+
+// Sum returns the sum of the values of the named metric whose labels match
+// all of the given label values.
+func (s MetricsSnapshot) Sum(name string, labels map[string]string) float64 {
+	var sum float64
+	for _, sample := range s.Samples(name, labels) {
+		sum += float64(sample.Value)
+	}
+	return sum
+}
+
+func sampleMatches(sample *model.Sample, labels map[string]string) bool {
+	for k, v := range labels {
+		if string(sample.Metric[model.LabelName(k)]) != v {
+			return false
+		}
+	}
+	return true
+}
+
+// AssertNoMetricIncrease fails if any series of the named counter whose labels
+// match all of the given regular expressions increased between before and
+// after, e.g. apiserver_request_total with {"code": "5.."} to catch server
+// errors caused by an operation. The expressions must match the whole label
+// value.
+func AssertNoMetricIncrease(t *testing.T, before, after MetricsSnapshot, metricName string, labelMatchers map[string]string) {
+	t.Helper()
+
+	matchers := make(map[model.LabelName]*regexp.Regexp, len(labelMatchers))
+	for name, expr := range labelMatchers {
+		re, err := regexp.Compile("^(?:" + expr + ")$")
+		require.NoError(t, err, "invalid matcher for label %q", name)
+		matchers[model.LabelName(name)] = re
+	}
+	matches := func(sample *model.Sample) bool {
+		for name, re := range matchers {
+			if !re.MatchString(string(sample.Metric[name])) {
+				return false
+			}
+		}
+		return true
+	}
+
+	previous := map[model.Fingerprint]model.SampleValue{}
+	for _, sample := range before[metricName] {
+		if matches(sample) {
+			previous[sample.Metric.Fingerprint()] = sample.Value
+		}
+	}
+
+	var increases []string
+	for _, sample := range after[metricName] {
+		if !matches(sample) {
+			continue
+		}
+		if prev := previous[sample.Metric.Fingerprint()]; sample.Value > prev {
+			increases = append(increases, fmt.Sprintf("%s: %v -> %v", sample.Metric, prev, sample.Value))
+		}
+	}
+	sort.Strings(increases)
+	require.Empty(t, increases, "%s increased", metricName)
+}
+
+// WaitForStorageObjectCount waits until apiserver_storage_objects of the server
+// behind cfg reports expected objects of the given resource. The count covers
+// all logical clusters of the shard and is only refreshed every
+// --etcd-count-metric-poll-period, see WithEtcdCountMetricPollPeriod. cfg must
+// be allowed to read metrics, e.g. a RootShardSystemMasterBaseConfig.
+func WaitForStorageObjectCount(ctx context.Context, t *testing.T, cfg *rest.Config, resource schema.GroupResource, expected int64) {
+	t.Helper()
+
+	const metricName = "apiserver_storage_objects"
+	labels := map[string]string{"resource": resource.String()}
+	t.Logf("Waiting for %s of %s to be %d on %s", metricName, resource, expected, cfg.Host)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		metrics, err := scrapeMetrics(ctx, cfg)
+		if err != nil {
+			return false, err.Error()
+		}
+		samples := metrics.Samples(metricName, labels)
+		if len(samples) == 0 {
+			return false, fmt.Sprintf("%s of %s not found", metricName, resource)
+		}
+		count := int64(metrics.Sum(metricName, labels))
+		return count == expected, fmt.Sprintf("%s of %s is %d", metricName, resource, count)
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "%s of %s did not become %d on %s", metricName, resource, expected, cfg.Host)
+}
+
+// HeapDelta returns the number of bytes the server behind cfg allocated on the
+// heap while op ran, as reported by go_memstats_alloc_bytes_total. The counter
+// is process wide, so allocations of background work and of the scrape itself
+// are included. cfg must be allowed to read metrics, e.g. a
+// RootShardSystemMasterBaseConfig.
+func HeapDelta(ctx context.Context, t *testing.T, cfg *rest.Config, op func()) int64 {
+	t.Helper()
+
+	const metricName = "go_memstats_alloc_bytes_total"
+	before := GetMetricsSnapshot(ctx, t, cfg)
+	require.NotEmpty(t, before[metricName], "%s not found in metrics of %s", metricName, cfg.Host)
+	op()
+	after := GetMetricsSnapshot(ctx, t, cfg)
+
+	delta := int64(after.Sum(metricName, nil) - before.Sum(metricName, nil))
+	t.Logf("Server %s allocated %d bytes", cfg.Host, delta)
+	return delta
+}
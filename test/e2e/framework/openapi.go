@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubernetesclientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/kube-openapi/pkg/spec3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// FetchOpenAPIV3 fetches and parses the OpenAPI v3 document of the given group
+// version from /openapi/v3 of the server behind cfg. The empty group is the
+// legacy core group served at /openapi/v3/api/v1. cfg must be scoped to a
+// logical cluster, e.g. via AdminConfigForCluster, because the schemas of
+// bound APIs differ per workspace.
+func FetchOpenAPIV3(ctx context.Context, cfg *rest.Config, gv schema.GroupVersion) (*spec3.OpenAPI, error) {
+	client, err := kubernetesclientset.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct client: %w", err)
+	}
+
+	path := "/openapi/v3/apis/" + gv.Group + "/" + gv.Version
+	if gv.Group == "" {
+		path = "/openapi/v3/api/" + gv.Version
+	}
+	raw, err := client.RESTClient().Get().AbsPath(path).SetHeader("Accept", "application/json").DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", path, err)
+	}
+
+	var doc spec3.OpenAPI
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &doc, nil
+}
+
+// FetchOpenAPIV2 fetches and parses the swagger document served at /openapi/v2
+// of the server behind cfg. Unlike /openapi/v3, it is not aggregated per
+// workspace and only has the definitions of the built-in APIs of kcp.
+func FetchOpenAPIV2(ctx context.Context, cfg *rest.Config) (*spec.Swagger, error) {
+	client, err := kubernetesclientset.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct client: %w", err)
+	}
+
+	raw, err := client.RESTClient().Get().AbsPath("/openapi/v2").SetHeader("Accept", "application/json").DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get /openapi/v2: %w", err)
+	}
+
+	var doc spec.Swagger
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse /openapi/v2: %w", err)
+	}
+	return &doc, nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+)
+
+var (
+	partitionsLock sync.Mutex
+	// partitions holds the iptables rule arguments of every partitioned
+	// shard, keyed by the host and port of the shard.
+	partitions = map[string][]string{}
+)
+
+// PartitionShard cuts the shard off the network by dropping all TCP traffic
+// to its port with iptables, for clients, the front-proxy and other shards
+// alike. The partition is healed by HealShard or at the end of the test. It
+// requires Linux, iptables and root privileges and skips the test otherwise.
+// Partitioning affects every process talking to the shard, including other test
+// packages, so only partition shards of private servers, e.g. started with
+// kcptestingserver.WithShards.
+func PartitionShard(t *testing.T, server kcptestingserver.RunningServer, shard string) {
+	t.Helper()
+
+	if runtime.GOOS != "linux" {
+		t.Skipf("Partitioning shards requires Linux, running on %s", runtime.GOOS)
+	}
+	if os.Geteuid() != 0 {
+		t.Skip("Partitioning shards requires root privileges")
+	}
+	if _, err := exec.LookPath("iptables"); err != nil {
+		t.Skip("Partitioning shards requires iptables")
+	}
+
+	u := shardURL(t, server, shard)
+	rule := []string{"INPUT", "-p", "tcp", "--dport", u.Port(), "-j", "DROP"}
+
+	partitionsLock.Lock()
+	defer partitionsLock.Unlock()
+	_, found := partitions[u.Host]
+	require.False(t, found, "shard %s is already partitioned", shard)
+
+	t.Logf("Partitioning shard %s by dropping traffic to port %s", shard, u.Port())
+	out, err := exec.Command("iptables", append([]string{"-w", "-I"}, rule...)...).CombinedOutput()
+	require.NoError(t, err, "failed to partition shard %s: %s", shard, out)
+	partitions[u.Host] = rule
+
+	t.Cleanup(func() {
+		healShard(t, u.Host, shard)
+	})
+}
+
+// HealShard reverts PartitionShard for the shard of server.
+func HealShard(t *testing.T, server kcptestingserver.RunningServer, shard string) {
+	t.Helper()
+
+	require.True(t, healShard(t, shardURL(t, server, shard).Host, shard), "shard %s is not partitioned", shard)
+}
+
+func shardURL(t *testing.T, server kcptestingserver.RunningServer, shard string) *url.URL {
+	t.Helper()
+
+	u, err := url.Parse(server.ShardSystemMasterBaseConfig(t, shard).Host)
+	require.NoError(t, err, "failed to parse URL of shard %s", shard)
+	require.NotEmpty(t, u.Port(), "URL of shard %s has no port", shard)
+	return u
+}
+
+func healShard(t *testing.T, host, shard string) bool {
+	partitionsLock.Lock()
+	defer partitionsLock.Unlock()
+	rule, found := partitions[host]
+	if !found {
+		return false
+	}
+
+	t.Logf("Healing partition of shard %s", shard)
+	if out, err := exec.Command("iptables", append([]string{"-w", "-D"}, rule...)...).CombinedOutput(); err != nil {
+		t.Errorf("failed to heal partition of shard %s: %v: %s", shard, err, out)
+	}
+	delete(partitions, host)
+	return true
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/client-go/rest"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/tenancy/initialization"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	"github.com/kcp-dev/kcp/sdk/apis/third_party/conditions/util/conditions"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+)
+
+// NewWorkspaceTypeWithQuotas creates the WorkspaceType path:name with an
+// initializer and, until the test ends, initializes every workspace of that
+// type by creating the given ResourceQuotas in it, creating their namespaces
+// if needed. The quotas are templates, i.e. only name, namespace, labels,
+// annotations and spec are copied. Enforcing the quotas requires the "quota"
+// controller, initializing workspaces the "workspace-scheduler" and
+// "apibinder" controllers. All of them run by default, see
+// kcptestingserver.WithQuotaControllers.
+func NewWorkspaceTypeWithQuotas(ctx context.Context, t *testing.T, server kcptestingserver.RunningServer, path logicalcluster.Path, name string, quotas ...corev1.ResourceQuota) *tenancyv1alpha1.WorkspaceType {
+	t.Helper()
+
+	cfg := server.BaseConfig(t)
+	kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	t.Logf("Creating WorkspaceType %s|%s with %d quota templates", path, name, len(quotas))
+	wt, err := kcpClusterClient.Cluster(path).TenancyV1alpha1().WorkspaceTypes().Create(ctx, &tenancyv1alpha1.WorkspaceType{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       tenancyv1alpha1.WorkspaceTypeSpec{Initializer: true},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create WorkspaceType %s|%s", path, name)
+
+	kcptestinghelpers.EventuallyReady(t, func() (conditions.Getter, error) {
+		wt, err = kcpClusterClient.Cluster(path).TenancyV1alpha1().WorkspaceTypes().Get(ctx, name, metav1.GetOptions{})
+		return wt, err
+	}, "WorkspaceType %s|%s did not become ready", path, name)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		wt, err = kcpClusterClient.Cluster(path).TenancyV1alpha1().WorkspaceTypes().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Sprintf("failed to get WorkspaceType: %v", err)
+		}
+		return len(wt.Status.VirtualWorkspaces) > 0, "no virtual workspace URLs published yet"
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "WorkspaceType %s|%s has no virtual workspace URLs", path, name)
+
+	initializer := initialization.InitializerForType(wt)
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+	for _, vw := range wt.Status.VirtualWorkspaces {
+		vwConfig := rest.CopyConfig(cfg)
+		vwConfig.Host = vw.URL
+		vwKcpClusterClient, err := kcpclientset.NewForConfig(vwConfig)
+		require.NoError(t, err, "failed to construct kcp cluster client for %s", vw.URL)
+		vwKubeClusterClient, err := kcpkubernetesclientset.NewForConfig(vwConfig)
+		require.NoError(t, err, "failed to construct kube cluster client for %s", vw.URL)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// a workspace that is never initialized fails the test waiting
+			// for it to become ready
+			_ = wait.PollUntilContextCancel(ctx, 100*time.Millisecond, true, func(ctx context.Context) (bool, error) {
+				clusters, err := vwKcpClusterClient.CoreV1alpha1().LogicalClusters().List(ctx, metav1.ListOptions{})
+				if err != nil {
+					return false, nil //nolint:nilerr // retry
+				}
+				for i := range clusters.Items {
+					lc := &clusters.Items[i]
+					if !initialization.InitializerPresent(initializer, lc.Status.Initializers) {
+						continue
+					}
+					if err := initializeWithQuotas(ctx, vwKcpClusterClient, vwKubeClusterClient, lc, initializer, quotas); err != nil && ctx.Err() == nil {
+						t.Logf("Failed to initialize logical cluster %s with quotas, retrying: %v", logicalcluster.From(lc), err)
+					}
+				}
+				return false, nil
+			})
+		}()
+	}
+
+	return wt
+}
+
+func initializeWithQuotas(ctx context.Context, kcpClusterClient kcpclientset.ClusterInterface, kubeClusterClient kcpkubernetesclientset.ClusterInterface, lc *corev1alpha1.LogicalCluster, initializer corev1alpha1.LogicalClusterInitializer, quotas []corev1.ResourceQuota) error {
+	clusterPath := logicalcluster.From(lc).Path()
+	for _, quota := range quotas {
+		namespace := quota.Namespace
+		if namespace == "" {
+			namespace = metav1.NamespaceDefault
+		}
+		_, err := kubeClusterClient.Cluster(clusterPath).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}, metav1.CreateOptions{})
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		_, err = kubeClusterClient.Cluster(clusterPath).CoreV1().ResourceQuotas(namespace).Create(ctx, &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        quota.Name,
+				Labels:      quota.Labels,
+				Annotations: quota.Annotations,
+			},
+			Spec: quota.Spec,
+		}, metav1.CreateOptions{})
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"resourceVersion": lc.ResourceVersion},
+		"status": map[string]interface{}{
+			"initializers": initialization.EnsureInitializerAbsent(initializer, lc.Status.Initializers),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = kcpClusterClient.CoreV1alpha1().LogicalClusters().Cluster(clusterPath).Patch(ctx, corev1alpha1.LogicalClusterName, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	return err
+}
+
+// AssertWorkspaceQuota fails unless the ResourceQuota namespace/name exists in
+// the workspace path and its status, once computed by the quota controller,
+// reports the expected hard limits.
+func AssertWorkspaceQuota(ctx context.Context, t *testing.T, client kcpkubernetesclientset.ClusterInterface, path logicalcluster.Path, namespace, name string, expected corev1.ResourceList) {
+	t.Helper()
+
+	t.Logf("Waiting for ResourceQuota %s/%s in %s to enforce %v", namespace, name, path, expected)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		quota, err := client.Cluster(path).CoreV1().ResourceQuotas(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Sprintf("failed to get ResourceQuota: %v", err)
+		}
+		if !quotav1.Equals(quota.Spec.Hard, expected) {
+			return false, fmt.Sprintf("ResourceQuota has hard limits %v in its spec", quota.Spec.Hard)
+		}
+		if !quotav1.Equals(quota.Status.Hard, expected) {
+			return false, fmt.Sprintf("ResourceQuota enforces hard limits %v", quota.Status.Hard)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "ResourceQuota %s/%s in %s does not enforce %v", namespace, name, path, expected)
+}
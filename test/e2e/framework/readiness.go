@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubernetesclientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+)
+
+// WaitForPostStartHook waits for the post-start hook hookName of the server
+// behind cfg to complete, i.e. for /readyz/poststarthook/<hookName> to return
+// success. It fails immediately if the server has no hook of that name. cfg
+// must point to a shard, e.g. a RootShardSystemMasterBaseConfig.
+func WaitForPostStartHook(ctx context.Context, t *testing.T, cfg *rest.Config, hookName string) {
+	t.Helper()
+
+	client, err := kubernetesclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct client for readiness checks")
+
+	path := "/readyz/poststarthook/" + hookName
+	t.Logf("Waiting for post-start hook %s of %s to complete", hookName, cfg.Host)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		_, err := client.RESTClient().Get().AbsPath(path).DoRaw(ctx)
+		if apierrors.IsNotFound(err) {
+			require.NoError(t, err, "server %s has no post-start hook %s", cfg.Host, hookName)
+		}
+		if err != nil {
+			return false, fmt.Sprintf("post-start hook not completed: %v", err)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "post-start hook %s of %s did not complete", hookName, cfg.Host)
+}
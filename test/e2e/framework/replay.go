@@ -0,0 +1,247 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// RecordedOperation is a mutating API call captured by an OperationRecorder.
+// Error is the reason of the API error the call failed with, empty if it
+// succeeded.
+type RecordedOperation struct {
+	Verb         string                     `json:"verb"`
+	Group        string                     `json:"group,omitempty"`
+	Version      string                     `json:"version"`
+	Resource     string                     `json:"resource"`
+	Namespace    string                     `json:"namespace,omitempty"`
+	Name         string                     `json:"name,omitempty"`
+	Subresources []string                   `json:"subresources,omitempty"`
+	Object       *unstructured.Unstructured `json:"object,omitempty"`
+	PatchType    types.PatchType            `json:"patchType,omitempty"`
+	Patch        json.RawMessage            `json:"patch,omitempty"`
+	Error        metav1.StatusReason        `json:"error,omitempty"`
+}
+
+// GroupVersionResource returns the resource the operation was made against.
+func (op RecordedOperation) GroupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: op.Group, Version: op.Version, Resource: op.Resource}
+}
+
+// OperationRecorder is a dynamic client that records the create, update, patch
+// and delete calls made through it, e.g. to write the sequence that made a test
+// fail to a file for ReplayOperations. Calls that fail without reaching the
+// server are not recorded. Reads are passed through as is.
+type OperationRecorder struct {
+	client dynamic.Interface
+
+	lock       sync.Mutex
+	operations []RecordedOperation
+}
+
+var _ dynamic.Interface = &OperationRecorder{}
+
+// NewOperationRecorder returns an OperationRecorder making its calls with
+// client.
+func NewOperationRecorder(client dynamic.Interface) *OperationRecorder {
+	return &OperationRecorder{client: client}
+}
+
+// Resource implements dynamic.Interface.
+func (r *OperationRecorder) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return &recordingResource{
+		recordingNamespacedResource: recordingNamespacedResource{ResourceInterface: r.client.Resource(gvr), recorder: r, gvr: gvr},
+		client:                      r.client.Resource(gvr),
+	}
+}
+
+// Operations returns the operations recorded so far, in the order they were
+// made.
+func (r *OperationRecorder) Operations() []RecordedOperation {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return append([]RecordedOperation(nil), r.operations...)
+}
+
+// Save writes the operations recorded so far to path as JSON, for use with
+// ReplayOperations.
+func (r *OperationRecorder) Save(t *testing.T, path string) {
+	t.Helper()
+
+	operations := r.Operations()
+	data, err := json.MarshalIndent(operations, "", "  ")
+	require.NoError(t, err, "failed to marshal recorded operations")
+	require.NoError(t, os.WriteFile(path, data, 0o644), "failed to write recorded operations")
+	t.Logf("Wrote %d recorded operations to %s", len(operations), path)
+}
+
+func (r *OperationRecorder) record(op RecordedOperation, err error) {
+	if err != nil {
+		var status apierrors.APIStatus
+		if !errors.As(err, &status) {
+			return
+		}
+		op.Error = apierrors.ReasonForError(err)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.operations = append(r.operations, op)
+}
+
+type recordingResource struct {
+	recordingNamespacedResource
+	client dynamic.NamespaceableResourceInterface
+}
+
+func (r *recordingResource) Namespace(namespace string) dynamic.ResourceInterface {
+	return &recordingNamespacedResource{
+		ResourceInterface: r.client.Namespace(namespace),
+		recorder:          r.recorder,
+		gvr:               r.gvr,
+		namespace:         namespace,
+	}
+}
+
+type recordingNamespacedResource struct {
+	dynamic.ResourceInterface
+	recorder  *OperationRecorder
+	gvr       schema.GroupVersionResource
+	namespace string
+}
+
+func (r *recordingNamespacedResource) operation(verb, name string, subresources []string) RecordedOperation {
+	return RecordedOperation{
+		Verb:         verb,
+		Group:        r.gvr.Group,
+		Version:      r.gvr.Version,
+		Resource:     r.gvr.Resource,
+		Namespace:    r.namespace,
+		Name:         name,
+		Subresources: subresources,
+	}
+}
+
+func (r *recordingNamespacedResource) Create(ctx context.Context, obj *unstructured.Unstructured, options metav1.CreateOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	op := r.operation("create", obj.GetName(), subresources)
+	op.Object = recordedObject(obj)
+	ret, err := r.ResourceInterface.Create(ctx, obj, options, subresources...)
+	r.recorder.record(op, err)
+	return ret, err
+}
+
+func (r *recordingNamespacedResource) Update(ctx context.Context, obj *unstructured.Unstructured, options metav1.UpdateOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	op := r.operation("update", obj.GetName(), subresources)
+	op.Object = recordedObject(obj)
+	ret, err := r.ResourceInterface.Update(ctx, obj, options, subresources...)
+	r.recorder.record(op, err)
+	return ret, err
+}
+
+func (r *recordingNamespacedResource) UpdateStatus(ctx context.Context, obj *unstructured.Unstructured, options metav1.UpdateOptions) (*unstructured.Unstructured, error) {
+	return r.Update(ctx, obj, options, "status")
+}
+
+func (r *recordingNamespacedResource) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, options metav1.PatchOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	op := r.operation("patch", name, subresources)
+	op.PatchType = pt
+	op.Patch = append(json.RawMessage(nil), data...)
+	ret, err := r.ResourceInterface.Patch(ctx, name, pt, data, options, subresources...)
+	r.recorder.record(op, err)
+	return ret, err
+}
+
+func (r *recordingNamespacedResource) Delete(ctx context.Context, name string, options metav1.DeleteOptions, subresources ...string) error {
+	op := r.operation("delete", name, subresources)
+	err := r.ResourceInterface.Delete(ctx, name, options, subresources...)
+	r.recorder.record(op, err)
+	return err
+}
+
+// recordedObject returns a copy of obj without the metadata set by the server,
+// which would not match the objects on the server the operations are replayed
+// against.
+func recordedObject(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	obj = obj.DeepCopy()
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+	obj.SetCreationTimestamp(metav1.Time{})
+	obj.SetGeneration(0)
+	obj.SetManagedFields(nil)
+	obj.SetSelfLink("")
+	return obj
+}
+
+// ReplayOperations reads the operations in opsFile, as written by
+// OperationRecorder.Save, and makes them in order with client. Each operation
+// must succeed, or fail with the same reason as when it was recorded. Updates
+// are made against the current resourceVersion of the object, so recorded
+// conflicts are not reproduced.
+func ReplayOperations(ctx context.Context, t *testing.T, client dynamic.Interface, opsFile string) {
+	t.Helper()
+
+	data, err := os.ReadFile(opsFile)
+	require.NoError(t, err, "failed to read recorded operations")
+	var operations []RecordedOperation
+	require.NoError(t, json.Unmarshal(data, &operations), "failed to parse recorded operations from %s", opsFile)
+
+	t.Logf("Replaying %d operations from %s", len(operations), opsFile)
+	for i, op := range operations {
+		resource := client.Resource(op.GroupVersionResource()).Namespace(op.Namespace)
+		switch op.Verb {
+		case "create":
+			require.NotNil(t, op.Object, "operation %d: create without object", i)
+			_, err = resource.Create(ctx, op.Object, metav1.CreateOptions{}, op.Subresources...)
+		case "update":
+			require.NotNil(t, op.Object, "operation %d: update without object", i)
+			obj := op.Object.DeepCopy()
+			var current *unstructured.Unstructured
+			if current, err = resource.Get(ctx, op.Name, metav1.GetOptions{}); err == nil {
+				obj.SetResourceVersion(current.GetResourceVersion())
+				_, err = resource.Update(ctx, obj, metav1.UpdateOptions{}, op.Subresources...)
+			}
+		case "patch":
+			_, err = resource.Patch(ctx, op.Name, op.PatchType, op.Patch, metav1.PatchOptions{}, op.Subresources...)
+		case "delete":
+			err = resource.Delete(ctx, op.Name, metav1.DeleteOptions{}, op.Subresources...)
+		default:
+			require.Failf(t, "unknown verb", "operation %d: %q", i, op.Verb)
+		}
+
+		if op.Error == "" {
+			require.NoError(t, err, "operation %d: %s %s %s/%s failed", i, op.Verb, op.GroupVersionResource(), op.Namespace, op.Name)
+			continue
+		}
+		require.Error(t, err, "operation %d: %s %s %s/%s succeeded, but was recorded to fail with %s", i, op.Verb, op.GroupVersionResource(), op.Namespace, op.Name, op.Error)
+		require.Equal(t, op.Error, apierrors.ReasonForError(err), "operation %d: %s %s %s/%s failed differently: %v", i, op.Verb, op.GroupVersionResource(), op.Namespace, op.Name, err)
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+)
+
+// WaitForReplicated waits until the object returned by getFromA, e.g. from the
+// shard it was created on, is also returned by getFromB, e.g. from the cache
+// server or another shard reading through it. Both must return the same object
+// as identified by its UID. The replication latency, measured from the first
+// successful read from A, is logged and returned.
+func WaitForReplicated(ctx context.Context, t *testing.T, getFromA, getFromB func(ctx context.Context) (metav1.Object, error)) time.Duration {
+	t.Helper()
+
+	var source metav1.Object
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		var err error
+		source, err = getFromA(ctx)
+		if err != nil {
+			return false, fmt.Sprintf("failed to get source object: %v", err)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "source object did not become readable")
+	require.NotEmpty(t, source.GetUID(), "source object %s has no UID", source.GetName())
+
+	start := time.Now()
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		replica, err := getFromB(ctx)
+		if err != nil {
+			return false, fmt.Sprintf("failed to get replica: %v", err)
+		}
+		if replica.GetUID() != source.GetUID() {
+			return false, fmt.Sprintf("replica has UID %s, expected %s", replica.GetUID(), source.GetUID())
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "object %s was not replicated", source.GetName())
+	latency := time.Since(start)
+
+	t.Logf("Object %s was replicated after %s", source.GetName(), latency)
+	return latency
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+
+	kcpdiscovery "github.com/kcp-dev/client-go/discovery"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+)
+
+// RESTMapperForCluster returns a RESTMapper resolving the resources served in
+// the workspace clusterPath of server, including those bound from APIExports.
+// Discovery is cached on first use, call Reset to pick up resources that were
+// added since, e.g. when a mapping is not found yet for a freshly bound API.
+func RESTMapperForCluster(ctx context.Context, t *testing.T, server kcptestingserver.RunningServer, clusterPath logicalcluster.Path) meta.ResettableRESTMapper {
+	t.Helper()
+
+	discoveryClusterClient, err := kcpdiscovery.NewForConfig(rest.CopyConfig(server.BaseConfig(t)))
+	require.NoError(t, err, "failed to construct discovery cluster client")
+	discoveryClient := memory.NewMemCacheClient(discoveryClusterClient.Cluster(clusterPath))
+
+	// fail early if discovery does not work
+	err = wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, wait.ForeverTestTimeout, true, func(ctx context.Context) (bool, error) {
+		_, err := discoveryClient.ServerGroups()
+		return err == nil, nil
+	})
+	require.NoError(t, err, "discovery of workspace %s failed", clusterPath)
+
+	return restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
+}
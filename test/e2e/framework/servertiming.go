@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/rest"
+)
+
+// TraceInfo is the timing breakdown of the requests made in RequestTrace.
+type TraceInfo struct {
+	Requests []TracedRequest
+}
+
+// TracedRequest is the timing breakdown of a single request, as reported by
+// the Server-Timing header of its response.
+type TracedRequest struct {
+	Method string
+	URL    string
+	// Phases maps phase names like "filters", "storage" or "total" to the time
+	// spent in them, see kcpfilters.WithServerTiming.
+	Phases map[string]time.Duration
+}
+
+// Phase returns the time spent in the named phase, summed over all requests,
+// and whether any request reported it.
+func (i TraceInfo) Phase(name string) (time.Duration, bool) {
+	var sum time.Duration
+	var found bool
+	for _, req := range i.Requests {
+		if d, ok := req.Phases[name]; ok {
+			sum += d
+			found = true
+		}
+	}
+	return sum, found
+}
+
+// RequestTrace calls reqFn with a copy of cfg that records the Server-Timing
+// headers of all responses, and returns the timing breakdown of the requests
+// made. The server must be started with kcptestingserver.WithServerTiming,
+// responses without the header are not recorded.
+func RequestTrace(ctx context.Context, t *testing.T, cfg *rest.Config, reqFn func(ctx context.Context, cfg *rest.Config) error) TraceInfo {
+	t.Helper()
+
+	var (
+		lock  sync.Mutex
+		trace TraceInfo
+	)
+	cfg = rest.CopyConfig(cfg)
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := rt.RoundTrip(req)
+			if err != nil {
+				return resp, err
+			}
+			if header := resp.Header.Get("Server-Timing"); header != "" {
+				lock.Lock()
+				defer lock.Unlock()
+				trace.Requests = append(trace.Requests, TracedRequest{
+					Method: req.Method,
+					URL:    req.URL.String(),
+					Phases: parseServerTiming(header),
+				})
+			}
+			return resp, nil
+		})
+	})
+
+	require.NoError(t, reqFn(ctx, cfg), "traced requests failed")
+
+	lock.Lock()
+	defer lock.Unlock()
+	for _, req := range trace.Requests {
+		t.Logf("Request %s %s took %v", req.Method, req.URL, req.Phases)
+	}
+	return trace
+}
+
+// parseServerTiming parses a Server-Timing header like
+// "storage;dur=1.234, total;dur=5.678". Metrics without a duration are skipped.
+func parseServerTiming(header string) map[string]time.Duration {
+	phases := map[string]time.Duration{}
+	for _, metric := range strings.Split(header, ",") {
+		params := strings.Split(strings.TrimSpace(metric), ";")
+		for _, param := range params[1:] {
+			value, found := strings.CutPrefix(strings.TrimSpace(param), "dur=")
+			if !found {
+				continue
+			}
+			ms, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			phases[params[0]] = time.Duration(ms * float64(time.Millisecond))
+		}
+	}
+	return phases
+}
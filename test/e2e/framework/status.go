@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// AssertStatusOnly updates the status of an object with updateStatusFn, which
+// must go through the status subresource, and fails unless the spec of both
+// the returned object and the object read back with getFn still equals
+// originalSpec, compared by its JSON representation. Apart from status,
+// metadata.resourceVersion and metadata.managedFields the object read back must
+// also be unchanged, in particular metadata.generation must not be bumped.
+func AssertStatusOnly(ctx context.Context, t *testing.T, updateStatusFn, getFn func(ctx context.Context) (runtime.Object, error), originalSpec interface{}) {
+	t.Helper()
+
+	before, err := getFn(ctx)
+	require.NoError(t, err, "failed to get object before the status update")
+
+	updated, err := updateStatusFn(ctx)
+	require.NoError(t, err, "failed to update status")
+	assertSpec(t, updated, originalSpec, "updated")
+
+	after, err := getFn(ctx)
+	require.NoError(t, err, "failed to get object after the status update")
+	assertSpec(t, after, originalSpec, "read back")
+
+	require.Equal(t, withoutStatus(t, before), withoutStatus(t, after), "status update changed more than status")
+}
+
+func assertSpec(t *testing.T, obj runtime.Object, expected interface{}, what string) {
+	t.Helper()
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	require.NoError(t, err, "failed to convert %s object", what)
+	actual, _, err := unstructured.NestedFieldNoCopy(content, "spec")
+	require.NoError(t, err, "failed to get spec of %s object", what)
+
+	expectedJSON, err := json.Marshal(expected)
+	require.NoError(t, err, "failed to marshal original spec")
+	actualJSON, err := json.Marshal(actual)
+	require.NoError(t, err, "failed to marshal spec of %s object", what)
+	require.JSONEq(t, string(expectedJSON), string(actualJSON), "spec of %s object changed by status update", what)
+}
+
+func withoutStatus(t *testing.T, obj runtime.Object) map[string]interface{} {
+	t.Helper()
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	require.NoError(t, err, "failed to convert object")
+	content = runtime.DeepCopyJSON(content)
+	delete(content, "status")
+	unstructured.RemoveNestedField(content, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(content, "metadata", "managedFields")
+	return content
+}
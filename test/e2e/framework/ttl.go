@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// AssertExpiresWithin waits for an object with a TTL, e.g. an Event, to be
+// deleted and fails unless that happens within window from the call. getFn
+// must get the object and return its error, a NotFound error means it is gone.
+// It returns how long the deletion took. Call it right after creating the
+// object to measure against its TTL.
+func AssertExpiresWithin(ctx context.Context, t *testing.T, getFn func(ctx context.Context) error, window time.Duration) time.Duration {
+	t.Helper()
+
+	require.Greater(t, window, time.Duration(0), "expiry window must be positive")
+
+	start := time.Now()
+	t.Logf("Waiting up to %s for the object to expire", window)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	deadline := time.After(window)
+	var lastErr error
+	for {
+		err := getFn(ctx)
+		if apierrors.IsNotFound(err) {
+			elapsed := time.Since(start)
+			t.Logf("Object expired after %s", elapsed)
+			return elapsed
+		}
+		if err != nil {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			require.NoError(t, ctx.Err(), "context done while waiting for the object to expire")
+		case <-deadline:
+			require.Failf(t, "object did not expire", "object still exists after %s, last error: %v", window, lastErr)
+		case <-ticker.C:
+		}
+	}
+}
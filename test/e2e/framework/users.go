@@ -20,16 +20,21 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	authorizationv1 "k8s.io/api/authorization/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
 
 	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 	"github.com/kcp-dev/logicalcluster/v3"
 
 	"github.com/kcp-dev/kcp/pkg/authorization/bootstrap"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
 )
 
 // AdmitWorkspaceAccess create RBAC rules that allow the given users and/or groups to access the given workspace, optionally as admin.
@@ -77,3 +82,65 @@ func AdmitWorkspaceAccess(ctx context.Context, t *testing.T, kubeClusterClient k
 	_, err := kubeClusterClient.Cluster(clusterName).RbacV1().ClusterRoleBindings().Create(ctx, binding, metav1.CreateOptions{})
 	require.NoError(t, err)
 }
+
+// GrantRole creates a Role with the given rules and a RoleBinding to subject
+// in the given namespace of clusterPath, and waits until a SubjectAccessReview
+// allows every verb on every resource of the rules, i.e. until the grant is
+// effective in the authorizer. kubeClusterClient must be allowed to create RBAC
+// and SubjectAccessReviews. The subject still needs access to the workspace,
+// e.g. through AdmitWorkspaceAccess.
+func GrantRole(ctx context.Context, t *testing.T, kubeClusterClient kcpkubernetesclientset.ClusterInterface, clusterPath logicalcluster.Path, namespace string, subject rbacv1.Subject, rules []rbacv1.PolicyRule) {
+	t.Helper()
+
+	t.Logf("Granting %s %s a role in namespace %s of workspace %q", subject.Kind, subject.Name, namespace, clusterPath)
+	role, err := kubeClusterClient.Cluster(clusterPath).RbacV1().Roles(namespace).Create(ctx, &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "grant-"},
+		Rules:      rules,
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create role in namespace %s of workspace %q", namespace, clusterPath)
+
+	_, err = kubeClusterClient.Cluster(clusterPath).RbacV1().RoleBindings(namespace).Create(ctx, &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: role.Name},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     role.Name,
+		},
+		Subjects: []rbacv1.Subject{subject},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create role binding in namespace %s of workspace %q", namespace, clusterPath)
+
+	spec := authorizationv1.SubjectAccessReviewSpec{}
+	switch subject.Kind {
+	case rbacv1.UserKind:
+		spec.User = subject.Name
+	case rbacv1.GroupKind:
+		spec.Groups = []string{subject.Name}
+	case rbacv1.ServiceAccountKind:
+		spec.User = serviceaccount.MakeUsername(subject.Namespace, subject.Name)
+	default:
+		require.Failf(t, "unsupported subject", "subject kind %q is not supported", subject.Kind)
+	}
+
+	for _, rule := range rules {
+		for _, verb := range rule.Verbs {
+			for _, group := range rule.APIGroups {
+				for _, resource := range rule.Resources {
+					spec.ResourceAttributes = &authorizationv1.ResourceAttributes{
+						Namespace: namespace,
+						Verb:      verb,
+						Group:     group,
+						Resource:  resource,
+					}
+					kcptestinghelpers.Eventually(t, func() (bool, string) {
+						resp, err := kubeClusterClient.Cluster(clusterPath).AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{Spec: spec}, metav1.CreateOptions{})
+						if err != nil {
+							return false, fmt.Sprintf("failed to create SubjectAccessReview: %v", err)
+						}
+						return resp.Status.Allowed, fmt.Sprintf("not allowed yet: %s", resp.Status.Reason)
+					}, wait.ForeverTestTimeout, 100*time.Millisecond, "%s %s cannot %s %s in namespace %s of workspace %q", subject.Kind, subject.Name, verb, resource, namespace, clusterPath)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+)
+
+// AssertWatchExpires waits for a watch on gvr starting from oldResourceVersion
+// to fail with 410 Gone, i.e. for the revision to be compacted. client must be
+// scoped to a single logical cluster. Compaction happens periodically, so the
+// watch is retried until it expires.
+func AssertWatchExpires(ctx context.Context, t *testing.T, client dynamic.Interface, gvr schema.GroupVersionResource, oldResourceVersion string) {
+	t.Helper()
+
+	t.Logf("Waiting for a watch on %s from resourceVersion %s to expire", gvr.Resource, oldResourceVersion)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		w, err := client.Resource(gvr).Watch(ctx, metav1.ListOptions{ResourceVersion: oldResourceVersion})
+		if err != nil {
+			return isExpired(err), fmt.Sprintf("failed to watch: %v", err)
+		}
+		defer w.Stop()
+
+		select {
+		case ev, ok := <-w.ResultChan():
+			if !ok {
+				return false, "watch closed without an event"
+			}
+			if ev.Type != watch.Error {
+				return false, fmt.Sprintf("watch still delivers %s events", ev.Type)
+			}
+			err := apierrors.FromObject(ev.Object)
+			return isExpired(err), fmt.Sprintf("unexpected watch error: %v", err)
+		case <-time.After(5 * time.Second):
+			return false, "no watch event received"
+		}
+	}, wait.ForeverTestTimeout, time.Second, "watch on %s from resourceVersion %s did not expire", gvr.Resource, oldResourceVersion)
+}
+
+func isExpired(err error) bool {
+	return apierrors.IsResourceExpired(err) || apierrors.IsGone(err)
+}
+
+// StartReflector runs a reflector for gvr until the test ends and waits for its
+// initial list. client must be scoped to a single logical cluster. The
+// reflector relists whenever its watch expires, so the returned store recovers
+// from compaction and converges to the current state of the server.
+func StartReflector(ctx context.Context, t *testing.T, client dynamic.Interface, gvr schema.GroupVersionResource) cache.Store {
+	t.Helper()
+
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return client.Resource(gvr).List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return client.Resource(gvr).Watch(ctx, options)
+		},
+	}
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	reflector := cache.NewReflectorWithOptions(lw, &unstructured.Unstructured{}, store, cache.ReflectorOptions{
+		Name: "e2e-" + gvr.Resource,
+	})
+
+	ctx, cancel := context.WithCancel(ctx)
+	t.Cleanup(cancel)
+	go reflector.Run(ctx.Done())
+
+	t.Logf("Waiting for reflector on %s to sync", gvr.Resource)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		return reflector.LastSyncResourceVersion() != "", "reflector has not listed yet"
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "reflector on %s did not sync", gvr.Resource)
+
+	return store
+}
+
+// WatchLatency starts a watch on gvr, calls mutateFn and returns the time from
+// calling mutateFn until the watch delivered the event for the object it
+// returned, matched by name, namespace and resourceVersion. client must be
+// scoped to a single logical cluster. The event can arrive before mutateFn
+// returns, so the latency includes the request of mutateFn itself.
+func WatchLatency(ctx context.Context, t *testing.T, client dynamic.Interface, gvr schema.GroupVersionResource, mutateFn func(ctx context.Context) (*unstructured.Unstructured, error)) time.Duration {
+	t.Helper()
+
+	list, err := client.Resource(gvr).List(ctx, metav1.ListOptions{Limit: 1})
+	require.NoError(t, err, "failed to list %s", gvr.Resource)
+	w, err := client.Resource(gvr).Watch(ctx, metav1.ListOptions{ResourceVersion: list.GetResourceVersion()})
+	require.NoError(t, err, "failed to watch %s", gvr.Resource)
+	defer w.Stop()
+
+	type arrival struct {
+		event watch.Event
+		at    time.Time
+	}
+	arrivals := make(chan arrival, 100)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(arrivals)
+		for ev := range w.ResultChan() {
+			select {
+			case arrivals <- arrival{event: ev, at: time.Now()}:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	start := time.Now()
+	obj, err := mutateFn(ctx)
+	require.NoError(t, err, "failed to mutate %s", gvr.Resource)
+
+	timeout := time.After(wait.ForeverTestTimeout)
+	for {
+		select {
+		case a, ok := <-arrivals:
+			require.True(t, ok, "watch on %s closed before the event for %s/%s arrived", gvr.Resource, obj.GetNamespace(), obj.GetName())
+			if a.event.Type == watch.Error {
+				require.NoError(t, apierrors.FromObject(a.event.Object), "watch on %s failed", gvr.Resource)
+			}
+			got, ok := a.event.Object.(*unstructured.Unstructured)
+			if !ok || got.GetNamespace() != obj.GetNamespace() || got.GetName() != obj.GetName() || got.GetResourceVersion() != obj.GetResourceVersion() {
+				continue
+			}
+			latency := a.at.Sub(start)
+			t.Logf("%s event for %s %s/%s arrived after %s", a.event.Type, gvr.Resource, obj.GetNamespace(), obj.GetName(), latency)
+			return latency
+		case <-timeout:
+			require.Failf(t, "watch event did not arrive", "no event for %s %s/%s at resourceVersion %s", gvr.Resource, obj.GetNamespace(), obj.GetName(), obj.GetResourceVersion())
+		}
+	}
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+)
+
+// AssertWorkspaceFullyDeleted waits for the workspace ws in parent to be gone
+// and then verifies on the shard the workspace was scheduled on that neither
+// the backing LogicalCluster nor any namespace of the logical cluster
+// remains. ws must be read before issuing the deletion, e.g. as returned by
+// NewWorkspaceFixture, because its logical cluster and shard cannot be
+// resolved anymore once the deletion completed.
+func AssertWorkspaceFullyDeleted(ctx context.Context, t *testing.T, server kcptestingserver.RunningServer, parent logicalcluster.Path, ws *tenancyv1alpha1.Workspace) {
+	t.Helper()
+
+	name := ws.Name
+	clusterName := logicalcluster.Name(ws.Spec.Cluster)
+	require.NotEmpty(t, clusterName, "workspace %s has no logical cluster assigned", parent.Join(name))
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kcp client for server")
+
+	shard, err := kcptesting.WorkspaceShard(ctx, kcpClusterClient, ws)
+	require.NoError(t, err, "failed to determine shard for workspace %s", parent.Join(name))
+
+	// Access the shard directly, the logical cluster is not reachable through
+	// the workspace path anymore once the workspace is gone.
+	shardCfg := server.ShardSystemMasterBaseConfig(t, shard.Name)
+	shardKcpClusterClient, err := kcpclientset.NewForConfig(shardCfg)
+	require.NoError(t, err, "failed to construct kcp client for shard %s", shard.Name)
+	shardKubeClusterClient, err := kcpkubernetesclientset.NewForConfig(shardCfg)
+	require.NoError(t, err, "failed to construct kube client for shard %s", shard.Name)
+
+	t.Logf("Waiting for workspace %s to be deleted", parent.Join(name))
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		ws, err := kcpClusterClient.Cluster(parent).TenancyV1alpha1().Workspaces().Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, ""
+		}
+		if err != nil {
+			return false, fmt.Sprintf("failed to get workspace: %v", err)
+		}
+		return false, fmt.Sprintf("workspace still exists with finalizers %v", ws.Finalizers)
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "workspace %s was not deleted", parent.Join(name))
+
+	t.Logf("Waiting for LogicalCluster of %s to be deleted on shard %s", clusterName, shard.Name)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		lc, err := shardKcpClusterClient.Cluster(clusterName.Path()).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, ""
+		}
+		if err != nil {
+			return false, fmt.Sprintf("failed to get LogicalCluster: %v", err)
+		}
+		return false, fmt.Sprintf("LogicalCluster still exists in phase %s with finalizers %v", lc.Status.Phase, lc.Finalizers)
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "LogicalCluster of workspace %s was not deleted", parent.Join(name))
+
+	AssertNoOrphanedNamespaces(ctx, t, shardKubeClusterClient, clusterName.Path())
+}
+
+// AssertNoOrphanedNamespaces waits until no namespace remains in the logical
+// cluster at clusterPath, e.g. after the workspace or all the contents of the
+// logical cluster were deleted. Namespaces still terminating are waited for.
+// Once the workspace is gone its path does not resolve anymore, so pass a
+// client for the shard of the logical cluster and the logical cluster name as
+// path then.
+func AssertNoOrphanedNamespaces(ctx context.Context, t *testing.T, kubeClient kcpkubernetesclientset.ClusterInterface, clusterPath logicalcluster.Path) {
+	t.Helper()
+
+	t.Logf("Checking that no namespaces remain in logical cluster %s", clusterPath)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		nsList, err := kubeClient.Cluster(clusterPath).CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, ""
+		}
+		if err != nil {
+			return false, fmt.Sprintf("failed to list namespaces: %v", err)
+		}
+		if len(nsList.Items) > 0 {
+			names := make([]string, 0, len(nsList.Items))
+			for _, ns := range nsList.Items {
+				names = append(names, fmt.Sprintf("%s (%s)", ns.Name, ns.Status.Phase))
+			}
+			return false, fmt.Sprintf("namespaces still exist: %v", names)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "namespaces of logical cluster %s were not deleted", clusterPath)
+}
+
+// AssertWorkspaceIsolation verifies that objects of the given resource created
+// in one workspace are not visible in another. createFn is called once per
+// workspace and must create the same object (same name and namespace) in the
+// given workspace. The object created in pathA must not be visible in pathB
+// before it is created there too, and afterwards both workspaces must return
+// their own distinct object. Cluster-scoped resources are supported by
+// returning objects without namespace from createFn.
+func AssertWorkspaceIsolation(ctx context.Context, t *testing.T, client kcpdynamic.ClusterInterface, pathA, pathB logicalcluster.Path, gvr schema.GroupVersionResource, createFn func(ctx context.Context, path logicalcluster.Path) (*unstructured.Unstructured, error)) {
+	t.Helper()
+
+	resourceFor := func(path logicalcluster.Path, namespace string) dynamic.ResourceInterface {
+		if namespace == "" {
+			return client.Cluster(path).Resource(gvr)
+		}
+		return client.Cluster(path).Resource(gvr).Namespace(namespace)
+	}
+
+	t.Logf("Creating %s in workspace %s", gvr.Resource, pathA)
+	objA, err := createFn(ctx, pathA)
+	require.NoError(t, err, "failed to create %s in workspace %s", gvr.Resource, pathA)
+
+	t.Logf("Verifying %s %s is not visible in workspace %s", gvr.Resource, objA.GetName(), pathB)
+	_, err = resourceFor(pathB, objA.GetNamespace()).Get(ctx, objA.GetName(), metav1.GetOptions{})
+	require.True(t, apierrors.IsNotFound(err), "expected %s %s from %s to be not found in %s, got: %v", gvr.Resource, objA.GetName(), pathA, pathB, err)
+	list, err := resourceFor(pathB, objA.GetNamespace()).List(ctx, metav1.ListOptions{})
+	if !apierrors.IsNotFound(err) {
+		require.NoError(t, err, "failed to list %s in workspace %s", gvr.Resource, pathB)
+		for _, item := range list.Items {
+			require.NotEqual(t, objA.GetUID(), item.GetUID(), "%s %s from %s is listed in %s", gvr.Resource, objA.GetName(), pathA, pathB)
+		}
+	}
+
+	t.Logf("Creating the same %s in workspace %s", gvr.Resource, pathB)
+	objB, err := createFn(ctx, pathB)
+	require.NoError(t, err, "failed to create %s in workspace %s, it conflicts with %s", gvr.Resource, pathB, pathA)
+	require.NotEqual(t, objA.GetUID(), objB.GetUID(), "objects in %s and %s share the same UID", pathA, pathB)
+
+	t.Logf("Verifying each workspace returns its own %s", gvr.Resource)
+	gotA, err := resourceFor(pathA, objA.GetNamespace()).Get(ctx, objA.GetName(), metav1.GetOptions{})
+	require.NoError(t, err, "failed to get %s %s in workspace %s", gvr.Resource, objA.GetName(), pathA)
+	require.Equal(t, objA.GetUID(), gotA.GetUID(), "workspace %s returned the object of %s", pathA, pathB)
+	gotB, err := resourceFor(pathB, objB.GetNamespace()).Get(ctx, objB.GetName(), metav1.GetOptions{})
+	require.NoError(t, err, "failed to get %s %s in workspace %s", gvr.Resource, objB.GetName(), pathB)
+	require.Equal(t, objB.GetUID(), gotB.GetUID(), "workspace %s returned the object of %s", pathB, pathA)
+}
+
+// GetHomeWorkspace resolves the home workspace of the given user, provisioning
+// it on first access, and waits for it to become ready. client must act as
+// that user, e.g. be built from ConfigWithToken. The returned path is the
+// "user:<name>" alias of the home workspace.
+func GetHomeWorkspace(ctx context.Context, t *testing.T, client kcpclientset.ClusterInterface, user string) logicalcluster.Path {
+	t.Helper()
+
+	t.Logf("Waiting for home workspace of user %s to be ready", user)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		ws, err := client.Cluster(core.RootCluster.Path()).TenancyV1alpha1().Workspaces().Get(ctx, "~", metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Sprintf("failed to get home workspace: %v", err)
+		}
+		if ws.Status.Phase != corev1alpha1.LogicalClusterPhaseReady {
+			return false, fmt.Sprintf("home workspace is in phase %s", ws.Status.Phase)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "home workspace of user %s did not become ready", user)
+
+	home := logicalcluster.NewPath("user:" + user)
+	_, err := client.Cluster(home).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+	require.NoError(t, err, "failed to get LogicalCluster of home workspace %s", home)
+
+	return home
+}
+
+// AssertWorkspaceType fails unless the workspace parent:name has resolved to
+// the expected WorkspaceType, e.g. the default child type of its parent when it
+// was created without an explicit type.
+func AssertWorkspaceType(ctx context.Context, t *testing.T, client kcpclientset.ClusterInterface, parent logicalcluster.Path, name string, expected tenancyv1alpha1.WorkspaceTypeReference) {
+	t.Helper()
+
+	ws, err := client.Cluster(parent).TenancyV1alpha1().Workspaces().Get(ctx, name, metav1.GetOptions{})
+	require.NoError(t, err, "failed to get workspace %s", parent.Join(name))
+	require.NotNil(t, ws.Spec.Type, "workspace %s has no type", parent.Join(name))
+	require.Equal(t, expected, *ws.Spec.Type, "workspace %s has unexpected type", parent.Join(name))
+}
+
+// AssertWorkspacePathResolves verifies that every workspace along path, which
+// must start with root, exists and is Ready, and that the path resolves to the
+// logical cluster of the last workspace, which carries path as its canonical
+// path. It returns the name of that logical cluster.
+func AssertWorkspacePathResolves(ctx context.Context, t *testing.T, client kcpclientset.ClusterInterface, path logicalcluster.Path) logicalcluster.Name {
+	t.Helper()
+
+	require.True(t, path.HasPrefix(core.RootCluster.Path()), "workspace path %s does not start with %s", path, core.RootCluster)
+
+	segments := strings.Split(path.String(), ":")
+	parent := core.RootCluster.Path()
+	var ws *tenancyv1alpha1.Workspace
+	for _, name := range segments[1:] {
+		var err error
+		ws, err = client.Cluster(parent).TenancyV1alpha1().Workspaces().Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err, "failed to get workspace %s on the way to %s", parent.Join(name), path)
+		require.Equal(t, corev1alpha1.LogicalClusterPhaseReady, ws.Status.Phase, "workspace %s on the way to %s is not ready", parent.Join(name), path)
+		parent = parent.Join(name)
+	}
+
+	lc, err := client.Cluster(path).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+	require.NoError(t, err, "failed to get LogicalCluster of %s", path)
+	clusterName := logicalcluster.From(lc)
+	if ws != nil {
+		require.Equal(t, ws.Spec.Cluster, clusterName.String(), "path %s resolves to another logical cluster than its workspace", path)
+	}
+	require.Equal(t, path.String(), lc.Annotations[core.LogicalClusterPathAnnotationKey], "LogicalCluster %s has unexpected canonical path", clusterName)
+
+	return clusterName
+}
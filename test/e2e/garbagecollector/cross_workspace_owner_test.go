@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package garbagecollector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestGarbageCollectorCrossWorkspaceOwnerReference(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsA, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+	wsB, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kube cluster client for server")
+
+	t.Run("cross-workspace", func(t *testing.T) {
+		framework.AssertCrossWorkspaceOwnerRefRejected(ctx, t, kubeClusterClient, wsA, wsB)
+	})
+	t.Run("same workspace", func(t *testing.T) {
+		framework.AssertCrossWorkspaceOwnerRefRejected(ctx, t, kubeClusterClient, wsA, wsA)
+	})
+}
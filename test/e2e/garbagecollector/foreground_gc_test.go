@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package garbagecollector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestGarbageCollectorForegroundDeletion(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	cfg := server.BaseConfig(t)
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+	require.NoError(t, err, "error creating kube cluster client")
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "error creating dynamic cluster client")
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath, kcptesting.WithName("gc-foreground"))
+
+	t.Logf("Creating owner configmap")
+	owner, err := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("default").Apply(ctx,
+		corev1ac.ConfigMap("owner", "default"),
+		metav1.ApplyOptions{FieldManager: "e2e-test-runner"})
+	require.NoError(t, err, "Error applying owner configmap %s|default/owner", wsPath)
+
+	t.Logf("Creating owned configmap blocking the deletion of its owner")
+	owned, err := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("default").Apply(ctx,
+		corev1ac.ConfigMap("owned", "default").
+			WithOwnerReferences(metav1ac.OwnerReference().
+				WithAPIVersion("v1").
+				WithKind("ConfigMap").
+				WithName(owner.Name).
+				WithUID(owner.UID).
+				WithBlockOwnerDeletion(true)),
+		metav1.ApplyOptions{FieldManager: "e2e-test-runner"})
+	require.NoError(t, err, "Error applying owned configmap %s|default/owned", wsPath)
+
+	configMaps := corev1.SchemeGroupVersion.WithResource("configmaps")
+	framework.DeleteAndWaitForegroundGC(ctx, t, dynamicClusterClient.Cluster(wsPath),
+		framework.GCObject{GVR: configMaps, Namespace: "default", Name: owner.Name},
+		framework.GCObject{GVR: configMaps, Namespace: "default", Name: owned.Name},
+	)
+}
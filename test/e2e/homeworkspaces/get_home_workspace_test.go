@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package homeworkspaces
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcpclusterclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestGetHomeWorkspace(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t,
+		kcptestingserver.WithCustomArguments("--token-auth-file", framework.DefaultTokenAuthFile),
+		kcptestingserver.WithHomeWorkspaces(true),
+	)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	userConfig := framework.ConfigWithToken("user-1-token", rest.CopyConfig(server.BaseConfig(t)))
+	kcpUserClient, err := kcpclusterclientset.NewForConfig(userConfig)
+	require.NoError(t, err)
+
+	home := framework.GetHomeWorkspace(ctx, t, kcpUserClient, "user-1")
+	require.Equal(t, "user:user-1", home.String())
+
+	t.Logf("Verify ~ resolves to the same logical cluster as %s", home)
+	ws, err := kcpUserClient.Cluster(core.RootCluster.Path()).TenancyV1alpha1().Workspaces().Get(ctx, "~", metav1.GetOptions{})
+	require.NoError(t, err)
+	lc, err := kcpUserClient.Cluster(home).CoreV1alpha1().LogicalClusters().Get(ctx, "cluster", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, ws.Spec.Cluster, logicalcluster.From(lc).String(), "home workspace and %s point to different logical clusters", home)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/fixtures/apifixtures"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestOpenAPIv3BoundAPI(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	providerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+	consumerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "error creating kcp cluster client")
+
+	group := "wildwest.dev"
+	description := "sheriffs of the wild west"
+	apifixtures.CreateSheriffsSchemaAndExport(ctx, t, providerPath, kcpClusterClient, group, description)
+	apifixtures.BindToExport(ctx, t, providerPath, group, consumerPath, kcpClusterClient)
+
+	gv := schema.GroupVersion{Group: group, Version: "v1"}
+	consumerCfg := kcptestingserver.AdminConfigForCluster(t, server, consumerPath)
+
+	t.Logf("Waiting for Sheriff to appear in /openapi/v3 of %q", consumerPath)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		doc, err := framework.FetchOpenAPIV3(ctx, consumerCfg, gv)
+		if err != nil {
+			return false, err.Error()
+		}
+		if doc.Components == nil {
+			return false, "no components"
+		}
+		for name, s := range doc.Components.Schemas {
+			gvks, ok := s.Extensions["x-kubernetes-group-version-kind"].([]interface{})
+			if !ok || len(gvks) != 1 {
+				continue
+			}
+			if gvk, ok := gvks[0].(map[string]interface{}); !ok || gvk["kind"] != "Sheriff" {
+				continue
+			}
+			if s.Description != description {
+				return false, fmt.Sprintf("schema %s has description %q, expected %q", name, s.Description, description)
+			}
+			for _, property := range []string{"apiVersion", "kind", "metadata"} {
+				if _, found := s.Properties[property]; !found {
+					return false, fmt.Sprintf("schema %s is missing property %q", name, property)
+				}
+			}
+			return true, ""
+		}
+		return false, "no Sheriff schema found"
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "Sheriff schema did not appear in /openapi/v3 of %q", consumerPath)
+
+	t.Logf("Checking Sheriff is not in /openapi/v3 of provider %q", providerPath)
+	_, err = framework.FetchOpenAPIV3(ctx, kcptestingserver.AdminConfigForCluster(t, server, providerPath), gv)
+	require.Error(t, err, "expected %s not to be served in the provider workspace", gv)
+}
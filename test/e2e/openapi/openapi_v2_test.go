@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/fixtures/apifixtures"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// hasV2Definition returns whether doc has a definition of the given kind.
+func hasV2Definition(doc *spec.Swagger, group, kind string) bool {
+	for _, s := range doc.Definitions {
+		gvks, ok := s.Extensions["x-kubernetes-group-version-kind"].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, gvk := range gvks {
+			if gvk, ok := gvk.(map[string]interface{}); ok && gvk["group"] == group && gvk["kind"] == kind {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TestOpenAPIv2BoundAPI checks that /openapi/v2 serves the built-in APIs in
+// workspaces with bound APIs, and that bound APIs are only aggregated into
+// /openapi/v3.
+func TestOpenAPIv2BoundAPI(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	providerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+	consumerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "error creating kcp cluster client")
+
+	group := "wildwest.dev"
+	apifixtures.CreateSheriffsSchemaAndExport(ctx, t, providerPath, kcpClusterClient, group, "sheriffs of the wild west")
+	bound := time.Now()
+	apifixtures.BindToExport(ctx, t, providerPath, group, consumerPath, kcpClusterClient)
+
+	consumerCfg := kcptestingserver.AdminConfigForCluster(t, server, consumerPath)
+
+	t.Logf("Waiting for Sheriff to appear in /openapi/v3 of %q", consumerPath)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		_, err := framework.FetchOpenAPIV3(ctx, consumerCfg, schema.GroupVersion{Group: group, Version: "v1"})
+		if err != nil {
+			return false, err.Error()
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "Sheriff did not appear in /openapi/v3 of %q", consumerPath)
+	t.Logf("Aggregating Sheriff into /openapi/v3 took %s after binding", time.Since(bound))
+
+	t.Logf("Checking /openapi/v2 of %q", consumerPath)
+	doc, err := framework.FetchOpenAPIV2(ctx, consumerCfg)
+	require.NoError(t, err, "failed to fetch /openapi/v2")
+	require.True(t, hasV2Definition(doc, "", "ConfigMap"), "ConfigMap is missing in /openapi/v2")
+	require.False(t, hasV2Definition(doc, group, "Sheriff"), "bound Sheriff is unexpectedly in /openapi/v2, it is only aggregated into /openapi/v3")
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestKubeQuotaWorkspaceTypeTemplate(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "error creating kube cluster client")
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	parentPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	hard := corev1.ResourceList{"count/configmaps": resource.MustParse("2")}
+	wt := framework.NewWorkspaceTypeWithQuotas(ctx, t, server, parentPath, "quota-template", corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "template", Namespace: metav1.NamespaceDefault},
+		Spec:       corev1.ResourceQuotaSpec{Hard: hard},
+	})
+
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, parentPath, kcptesting.WithType(parentPath, "quota-template"))
+	t.Logf("Workspace %s of type %s was initialized", wsPath, wt.Name)
+	framework.AssertWorkspaceQuota(ctx, t, kubeClusterClient, wsPath, metav1.NamespaceDefault, "template", hard)
+
+	t.Logf("Make sure the quota is enforcing limits")
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{GenerateName: "quota-"}}
+		if _, err := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps(metav1.NamespaceDefault).Create(ctx, cm, metav1.CreateOptions{}); err != nil {
+			return apierrors.IsForbidden(err), err.Error()
+		}
+		return false, "expected an error trying to create a configmap"
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "quota never rejected configmap creation")
+
+	t.Logf("Make sure workspaces of other types do not get the quota")
+	otherPath, _ := kcptesting.NewWorkspaceFixture(t, server, parentPath)
+	_, err = kubeClusterClient.Cluster(otherPath).CoreV1().ResourceQuotas(metav1.NamespaceDefault).Get(ctx, "template", metav1.GetOptions{})
+	require.True(t, apierrors.IsNotFound(err), "expected no quota in %s, got: %v", otherPath, err)
+}
@@ -421,3 +421,58 @@ func TestAPIBindingEndpointSlicesSharded(t *testing.T) {
 		}, wait.ForeverTestTimeout*50, time.Millisecond*500)
 	}
 }
+
+func TestAPIExportEndpointSlicePartitionedShards(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	shards, err := kcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err, "failed to list shards")
+	if len(shards.Items) < 2 {
+		t.Skipf("Need at least 2 shards to run this test, got %d", len(shards.Items))
+	}
+	var shardNames []string
+	var regionShard, region string
+	for _, shard := range shards.Items {
+		shardNames = append(shardNames, shard.Name)
+		if regionShard == "" && shard.Labels["region"] != "" {
+			regionShard, region = shard.Name, shard.Labels["region"]
+		}
+	}
+	if regionShard == "" {
+		t.Skip("Need a shard with a region label to run this test")
+	}
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	providerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	t.Logf("Binding the APIExport in a consumer workspace on every shard")
+	var consumerPaths []logicalcluster.Path
+	for _, shard := range shards.Items {
+		consumerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath, kcptesting.WithShard(shard.Name))
+		consumerPaths = append(consumerPaths, consumerPath)
+	}
+	exportName := "partitioned"
+	framework.ShareAPIExport(ctx, t, kcpClusterClient, providerPath, consumerPaths, exportName, apisv1alpha2.APIExportSpec{})
+	export := apisv1alpha1.ExportBindingReference{
+		Path: providerPath.String(),
+		Name: exportName,
+	}
+
+	t.Logf("Verifying that a slice partitioned to region %s only lists the endpoint of shard %s", region, regionShard)
+	framework.NewPartitionedEndpointSlice(ctx, t, kcpClusterClient, providerPath, "region", export, &metav1.LabelSelector{
+		MatchLabels: map[string]string{"region": region},
+	})
+	framework.AssertEndpointSliceShards(ctx, t, kcpClusterClient, providerPath, "region", []string{regionShard})
+
+	t.Logf("Verifying that a slice partitioned to all shards lists the endpoints of all shards")
+	framework.NewPartitionedEndpointSlice(ctx, t, kcpClusterClient, providerPath, "all", export, &metav1.LabelSelector{})
+	framework.AssertEndpointSliceShards(ctx, t, kcpClusterClient, providerPath, "all", shardNames)
+}
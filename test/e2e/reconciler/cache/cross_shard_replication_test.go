@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	cacheclient "github.com/kcp-dev/kcp/pkg/cache/client"
+	"github.com/kcp-dev/kcp/pkg/cache/client/shard"
+	"github.com/kcp-dev/kcp/sdk/apis/apis"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// TestCrossShardReplication checks that a ClusterRole granting bind on
+// APIExports, created in a workspace on a non-root shard, is replicated to the
+// cache server the other shards read from.
+func TestCrossShardReplication(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+	shards, err := kcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	if len(shards.Items) < 2 {
+		t.Skipf("Need at least 2 shards to run this test, got %d", len(shards.Items))
+	}
+	var shardName string
+	for _, s := range shards.Items {
+		if s.Name != corev1alpha1.RootShard {
+			shardName = s.Name
+			break
+		}
+	}
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsPath, ws := kcptesting.NewWorkspaceFixture(t, server, orgPath, kcptesting.WithShard(shardName))
+	clusterName := logicalcluster.Name(ws.Spec.Cluster)
+
+	shardKubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.ShardSystemMasterBaseConfig(t, shardName))
+	require.NoError(t, err)
+	cacheClientConfig := createCacheClientConfigForEnvironment(ctx, t, server.RootShardSystemMasterBaseConfig(t))
+	cacheKcpClusterDynamicClient, err := kcpdynamic.NewForConfig(ClientRoundTrippersFor(cacheClientConfig))
+	require.NoError(t, err)
+
+	t.Logf("Creating a ClusterRole granting bind on APIExports in %s on shard %s", wsPath, shardName)
+	clusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "apiexport-binder"},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{apis.GroupName},
+			Resources: []string{"apiexports"},
+			Verbs:     []string{"bind"},
+		}},
+	}
+	_, err = shardKubeClusterClient.Cluster(clusterName.Path()).RbacV1().ClusterRoles().Create(ctx, clusterRole, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	framework.WaitForReplicated(ctx, t,
+		func(ctx context.Context) (metav1.Object, error) {
+			return shardKubeClusterClient.Cluster(clusterName.Path()).RbacV1().ClusterRoles().Get(ctx, clusterRole.Name, metav1.GetOptions{})
+		},
+		func(ctx context.Context) (metav1.Object, error) {
+			return cacheKcpClusterDynamicClient.Cluster(clusterName.Path()).Resource(rbacv1.SchemeGroupVersion.WithResource("clusterroles")).Get(cacheclient.WithShardInContext(ctx, shard.New(shardName)), clusterRole.Name, metav1.GetOptions{})
+		},
+	)
+}
+
+// TestReplicationControllers checks that with all controllers disabled, the
+// controllers enabled by WithReplicationControllers are enough for a ClusterRole
+// granting bind on APIExports to be replicated to the cache server.
+func TestReplicationControllers(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t,
+		kcptestingserver.WithCustomArguments("--run-controllers=false"),
+		kcptestingserver.WithReplicationControllers(),
+	)
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	rootShardConfig := server.RootShardSystemMasterBaseConfig(t)
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(rootShardConfig)
+	require.NoError(t, err)
+	cacheClientConfig := createCacheClientConfigForEnvironment(ctx, t, rootShardConfig)
+	cacheKcpClusterDynamicClient, err := kcpdynamic.NewForConfig(ClientRoundTrippersFor(cacheClientConfig))
+	require.NoError(t, err)
+
+	t.Logf("Creating a ClusterRole granting bind on APIExports in %s", core.RootCluster)
+	clusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "apiexport-binder-"},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{apis.GroupName},
+			Resources: []string{"apiexports"},
+			Verbs:     []string{"bind"},
+		}},
+	}
+	clusterRole, err = kubeClusterClient.Cluster(core.RootCluster.Path()).RbacV1().ClusterRoles().Create(ctx, clusterRole, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	framework.WaitForReplicated(ctx, t,
+		func(ctx context.Context) (metav1.Object, error) {
+			return kubeClusterClient.Cluster(core.RootCluster.Path()).RbacV1().ClusterRoles().Get(ctx, clusterRole.Name, metav1.GetOptions{})
+		},
+		func(ctx context.Context) (metav1.Object, error) {
+			return cacheKcpClusterDynamicClient.Cluster(core.RootCluster.Path()).Resource(rbacv1.SchemeGroupVersion.WithResource("clusterroles")).Get(cacheclient.WithShardInContext(ctx, shard.New(corev1alpha1.RootShard)), clusterRole.Name, metav1.GetOptions{})
+		},
+	)
+}
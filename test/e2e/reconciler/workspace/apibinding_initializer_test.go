@@ -22,14 +22,17 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/restmapper"
 
 	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
 
 	"github.com/kcp-dev/kcp/config/helpers"
 	apisv1alpha2 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
 	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
@@ -171,3 +174,87 @@ func TestWorkspaceTypesAPIBindingInitialization(t *testing.T) {
 	// This will create and wait for ready, which only happens if the APIBinding initialization is working correctly
 	_, _ = kcptesting.NewWorkspaceFixture(t, server, universalPath, kcptesting.WithType(universalPath, "test"), kcptesting.WithName("init"))
 }
+
+func TestWorkspaceTypeInheritedBindings(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	providerPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+	typesPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	cfg := server.BaseConfig(t)
+	kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "error creating kcp cluster client")
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+	require.NoError(t, err, "error creating kube cluster client")
+
+	exportName := "inherited"
+	t.Logf("Creating APIExport %s|%s that everybody may bind to", providerPath, exportName)
+	_, err = kcpClusterClient.Cluster(providerPath).ApisV1alpha2().APIExports().Create(ctx, &apisv1alpha2.APIExport{
+		ObjectMeta: metav1.ObjectMeta{Name: exportName},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "error creating APIExport")
+	_, err = kubeClusterClient.Cluster(providerPath).RbacV1().ClusterRoles().Create(ctx, &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "bind-" + exportName},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups:     []string{"apis.kcp.io"},
+			Resources:     []string{"apiexports"},
+			ResourceNames: []string{exportName},
+			Verbs:         []string{"bind"},
+		}},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "error creating ClusterRole")
+	_, err = kubeClusterClient.Cluster(providerPath).RbacV1().ClusterRoleBindings().Create(ctx, &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "bind-" + exportName},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     "bind-" + exportName,
+		},
+		Subjects: []rbacv1.Subject{{
+			APIGroup: rbacv1.GroupName,
+			Kind:     rbacv1.GroupKind,
+			Name:     "system:authenticated",
+		}},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "error creating ClusterRoleBinding")
+
+	baseBindings := []tenancyv1alpha1.APIExportReference{
+		{Path: providerPath.String(), Export: exportName},
+	}
+	childBindings := []tenancyv1alpha1.APIExportReference{
+		{Path: "root", Export: "tenancy.kcp.io"},
+	}
+
+	t.Logf("Creating WorkspaceType base with a default binding to %s|%s", providerPath, exportName)
+	_, err = kcpClusterClient.Cluster(typesPath).TenancyV1alpha1().WorkspaceTypes().Create(ctx, &tenancyv1alpha1.WorkspaceType{
+		ObjectMeta: metav1.ObjectMeta{Name: "base"},
+		Spec: tenancyv1alpha1.WorkspaceTypeSpec{
+			DefaultAPIBindings: baseBindings,
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "error creating WorkspaceType base")
+
+	t.Logf("Creating WorkspaceType child extending base")
+	_, err = kcpClusterClient.Cluster(typesPath).TenancyV1alpha1().WorkspaceTypes().Create(ctx, &tenancyv1alpha1.WorkspaceType{
+		ObjectMeta: metav1.ObjectMeta{Name: "child"},
+		Spec: tenancyv1alpha1.WorkspaceTypeSpec{
+			DefaultAPIBindings: childBindings,
+			Extend: tenancyv1alpha1.WorkspaceTypeExtension{
+				With: []tenancyv1alpha1.WorkspaceTypeReference{
+					{Name: "base", Path: typesPath.String()},
+				},
+			},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "error creating WorkspaceType child")
+
+	childPath, _ := kcptesting.NewWorkspaceFixture(t, server, typesPath, kcptesting.WithType(typesPath, "child"))
+	framework.AssertInheritedBindings(ctx, t, kcpClusterClient, childPath, append(baseBindings, childBindings...))
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestWorkspacePathResolution(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	path := orgPath
+	clusters := map[string]logicalcluster.Name{}
+	for _, name := range []string{"team", "project", "stage"} {
+		wsPath, ws := kcptesting.NewWorkspaceFixture(t, server, path, kcptesting.WithName("%s", name))
+		clusters[wsPath.String()] = logicalcluster.Name(ws.Spec.Cluster)
+		path = wsPath
+	}
+
+	t.Logf("Resolving the nested workspace paths down to %s", path)
+	for p, expected := range clusters {
+		require.Equal(t, expected, framework.AssertWorkspacePathResolves(ctx, t, kcpClusterClient, logicalcluster.NewPath(p)), "path %s resolved to an unexpected logical cluster", p)
+	}
+}
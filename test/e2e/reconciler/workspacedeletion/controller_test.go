@@ -313,6 +313,37 @@ func TestWorkspaceDeletion(t *testing.T) {
 	}
 }
 
+func TestWorkspaceFullDeletion(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	cfg := server.BaseConfig(t)
+	kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct client for server")
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kube client for server")
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsPath, ws := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	t.Logf("Create a namespace and a configmap in the workspace")
+	_, err = kubeClusterClient.Cluster(wsPath).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "full-deletion"}}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create namespace in workspace %s", wsPath)
+	_, err = kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("full-deletion").Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test"}}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create configmap in workspace %s", wsPath)
+
+	t.Logf("Delete workspace %s", wsPath)
+	err = kcpClusterClient.Cluster(orgPath).TenancyV1alpha1().Workspaces().Delete(ctx, ws.Name, metav1.DeleteOptions{})
+	require.NoError(t, err, "failed to delete workspace %s", wsPath)
+
+	framework.AssertWorkspaceFullyDeleted(ctx, t, server, orgPath, ws)
+}
+
 func toYAML(t *testing.T, obj interface{}) string {
 	t.Helper()
 	bs, err := yaml.Marshal(obj)
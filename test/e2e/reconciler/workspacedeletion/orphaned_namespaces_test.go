@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacedeletion
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestWorkspaceDeletionNoOrphanedNamespaces(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.SharedKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	cfg := server.BaseConfig(t)
+	kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct client for server")
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kube client for server")
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsPath, ws := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+	clusterName := logicalcluster.Name(ws.Spec.Cluster)
+
+	shard, err := kcptesting.WorkspaceShard(ctx, kcpClusterClient, ws)
+	require.NoError(t, err, "failed to determine shard for workspace %s", wsPath)
+	shardKubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.ShardSystemMasterBaseConfig(t, shard.Name))
+	require.NoError(t, err, "failed to construct kube client for shard %s", shard.Name)
+
+	t.Logf("Create namespaces with contents in workspace %s", wsPath)
+	for i := range 5 {
+		name := fmt.Sprintf("orphan-%d", i)
+		_, err := kubeClusterClient.Cluster(wsPath).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create namespace %s in workspace %s", name, wsPath)
+		_, err = kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps(name).Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "content"}}, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create configmap in namespace %s of workspace %s", name, wsPath)
+	}
+
+	nsList, err := shardKubeClusterClient.Cluster(clusterName.Path()).CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err, "failed to list namespaces of logical cluster %s", clusterName)
+	require.GreaterOrEqual(t, len(nsList.Items), 5, "namespaces are not visible on shard %s", shard.Name)
+
+	t.Logf("Delete workspace %s", wsPath)
+	err = kcpClusterClient.Cluster(orgPath).TenancyV1alpha1().Workspaces().Delete(ctx, ws.Name, metav1.DeleteOptions{})
+	require.NoError(t, err, "failed to delete workspace %s", wsPath)
+
+	// deletion of the namespaces is likely still in progress here, the
+	// helper waits for them to be gone
+	framework.AssertNoOrphanedNamespaces(ctx, t, shardKubeClusterClient, clusterName.Path())
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacedeletion
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestWorkspaceDeletionStuckFinalizer(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	// private, so that the goroutines of the server are not affected by
+	// other tests
+	server := kcptesting.PrivateKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	cfg := server.BaseConfig(t)
+	kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct client for server")
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kube client for server")
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct dynamic client for server")
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsPath, ws := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	t.Logf("Create a namespace and a configmap in the workspace")
+	_, err = kubeClusterClient.Cluster(wsPath).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "stuck"}}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create namespace in workspace %s", wsPath)
+	_, err = kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("stuck").Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test"}}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create configmap in workspace %s", wsPath)
+
+	metricsCfg := server.RootShardSystemMasterBaseConfig(t)
+	goroutinesBefore := framework.GetMetricsSnapshot(ctx, t, metricsCfg).Sum("go_goroutines", nil)
+
+	release := framework.InjectStuckFinalizer(ctx, t, dynamicClusterClient.Cluster(wsPath), corev1.SchemeGroupVersion.WithResource("configmaps"), "stuck", "test", "e2e.kcp.io/stuck")
+
+	t.Logf("Delete workspace %s", wsPath)
+	err = kcpClusterClient.Cluster(orgPath).TenancyV1alpha1().Workspaces().Delete(ctx, ws.Name, metav1.DeleteOptions{})
+	require.NoError(t, err, "failed to delete workspace %s", wsPath)
+
+	t.Logf("Waiting for the deletion to reach the configmap")
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		cm, err := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("stuck").Get(ctx, "test", metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Sprintf("failed to get configmap: %v", err)
+		}
+		return cm.DeletionTimestamp != nil, "configmap is not being deleted yet"
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "deletion of workspace %s did not reach the configmap", wsPath)
+
+	t.Logf("Verifying workspace %s stays in deletion while the finalizer is stuck", wsPath)
+	require.Never(t, func() bool {
+		current, err := kcpClusterClient.Cluster(orgPath).TenancyV1alpha1().Workspaces().Get(ctx, ws.Name, metav1.GetOptions{})
+		return err != nil || current.DeletionTimestamp == nil
+	}, 5*time.Second, 100*time.Millisecond, "workspace %s was deleted or left deletion despite the stuck finalizer", wsPath)
+
+	release()
+	framework.AssertWorkspaceFullyDeleted(ctx, t, server, orgPath, ws)
+
+	t.Logf("Waiting for the goroutines of the server to settle at %v", goroutinesBefore)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		goroutines := framework.GetMetricsSnapshot(ctx, t, metricsCfg).Sum("go_goroutines", nil)
+		// leave some room for unrelated background work
+		return goroutines <= goroutinesBefore+50, fmt.Sprintf("server has %v goroutines, %v before the deletion", goroutines, goroutinesBefore)
+	}, wait.ForeverTestTimeout, time.Second, "goroutines of the server leaked during the stuck deletion")
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestAccessLog(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithAccessLog("access.log"))
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+
+	t.Logf("Getting a missing namespace in %s", core.RootCluster.Path())
+	_, err = kubeClusterClient.Cluster(core.RootCluster.Path()).CoreV1().Namespaces().Get(ctx, "access-log-probe", metav1.GetOptions{})
+	require.Error(t, err, "expected the namespace to not exist")
+
+	t.Logf("Looking for the request in the access log at %s", kcptestingserver.AccessLogPath(server))
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		entries, err := framework.ParseAccessLog(server)
+		if err != nil {
+			return false, err.Error()
+		}
+		for _, entry := range entries {
+			if entry.Verb == "GET" && strings.HasSuffix(entry.URI, "/api/v1/namespaces/access-log-probe") {
+				require.Equal(t, 404, entry.Status, "unexpected status of %s", entry.URI)
+				return true, ""
+			}
+		}
+		return false, fmt.Sprintf("request not found in %d access log entries", len(entries))
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "request was not logged")
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestStructuredAuthenticationConfig(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	issuer := framework.StartJWTIssuer(t)
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithAuthenticationConfig(issuer.WriteAuthenticationConfig(t, "kcp-e2e", "oidc:")))
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	reviewAs := func(cfg *rest.Config) (*authenticationv1.SelfSubjectReview, error) {
+		kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+		require.NoError(t, err, "failed to construct kube cluster client")
+		return kubeClusterClient.Cluster(core.RootCluster.Path()).AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	}
+
+	t.Logf("Verifying a token of the configured issuer and audience authenticates")
+	var review *authenticationv1.SelfSubjectReview
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		// the authenticator fetches the signing keys of the issuer asynchronously
+		var err error
+		review, err = reviewAs(issuer.JWTConfig(t, server.BaseConfig(t), "kcp-e2e", "alice", "e2e-group"))
+		if err != nil {
+			return false, fmt.Sprintf("failed to review identity with a valid token: %v", err)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "valid token was not accepted")
+	require.Equal(t, "oidc:alice", review.Status.UserInfo.Username)
+	require.Contains(t, review.Status.UserInfo.Groups, "e2e-group")
+
+	t.Logf("Verifying a token for another audience is rejected")
+	_, err := reviewAs(issuer.JWTConfig(t, server.BaseConfig(t), "other-audience", "alice"))
+	require.True(t, apierrors.IsUnauthorized(err), "expected token for another audience to be rejected, got: %v", err)
+
+	t.Logf("Verifying a token of an unknown issuer is rejected")
+	other := framework.StartJWTIssuer(t)
+	_, err = reviewAs(other.JWTConfig(t, server.BaseConfig(t), "kcp-e2e", "alice"))
+	require.True(t, apierrors.IsUnauthorized(err), "expected token of an unknown issuer to be rejected, got: %v", err)
+}
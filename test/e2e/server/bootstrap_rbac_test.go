@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestBootstrapRBAC(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithBootstrapRBAC("e2e-admin", "cluster-admin"))
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	adminCfg := server.ClientCAUserConfig(t, rest.CopyConfig(server.BaseConfig(t)), "e2e-admin")
+	adminClient, err := kcpkubernetesclientset.NewForConfig(adminCfg)
+	require.NoError(t, err)
+
+	t.Logf("Creating and deleting a namespace in %s as the pre-bound user", core.RootCluster)
+	ns, err := adminClient.Cluster(core.RootCluster.Path()).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "bootstrap-rbac-"}}, metav1.CreateOptions{})
+	require.NoError(t, err, "pre-bound user cannot create namespaces")
+	err = adminClient.Cluster(core.RootCluster.Path()).CoreV1().Namespaces().Delete(ctx, ns.Name, metav1.DeleteOptions{})
+	require.NoError(t, err, "pre-bound user cannot delete namespaces")
+
+	t.Logf("Verifying an unbound user is still forbidden")
+	otherCfg := server.ClientCAUserConfig(t, rest.CopyConfig(server.BaseConfig(t)), "e2e-other")
+	otherClient, err := kcpkubernetesclientset.NewForConfig(otherCfg)
+	require.NoError(t, err)
+	_, err = otherClient.Cluster(core.RootCluster.Path()).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "bootstrap-rbac-"}}, metav1.CreateOptions{})
+	require.Error(t, err, "unbound user must not be able to create namespaces")
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// TestChaosRestartShard creates workspaces on a non-root shard while that shard
+// is killed and restarted, and checks that creating them eventually succeeds
+// and that they become ready.
+func TestChaosRestartShard(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithShards(2))
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+	workspaces := kcpClusterClient.Cluster(core.RootCluster.Path()).TenancyV1alpha1().Workspaces()
+
+	var created []string
+	failures := framework.ChaosRestart(ctx, t, server, "shard-1", func(ctx context.Context) error {
+		ws := &tenancyv1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{GenerateName: "chaos-"},
+		}
+		kcptesting.WithShard("shard-1")(ws)
+		ws, err := workspaces.Create(ctx, ws, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		created = append(created, ws.Name)
+		return nil
+	})
+	t.Logf("%d workspaces created, %d creations failed", len(created), failures)
+	require.NotEmpty(t, created)
+
+	for _, name := range created {
+		t.Logf("Waiting for workspace %s to become ready", name)
+		kcptestinghelpers.Eventually(t, func() (bool, string) {
+			ws, err := workspaces.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return false, err.Error()
+			}
+			return ws.Status.Phase == corev1alpha1.LogicalClusterPhaseReady, fmt.Sprintf("workspace %s is %s", name, ws.Status.Phase)
+		}, wait.ForeverTestTimeout, 100*time.Millisecond, "workspace %s did not become ready", name)
+	}
+}
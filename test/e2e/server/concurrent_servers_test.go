@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// TestConcurrentPrivateServers starts 20 private servers at the same time and
+// checks that all of them come up, on distinct ports.
+func TestConcurrentPrivateServers(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	const servers = 20
+
+	var lock sync.Mutex
+	hosts := map[string]int{}
+	t.Run("servers", func(t *testing.T) {
+		for i := range servers {
+			t.Run(fmt.Sprintf("server-%d", i), func(t *testing.T) {
+				t.Parallel()
+
+				server := kcptesting.PrivateKcpServer(t)
+				host := server.RootShardSystemMasterBaseConfig(t).Host
+
+				lock.Lock()
+				defer lock.Unlock()
+				if other, found := hosts[host]; found {
+					t.Errorf("servers %d and %d both listen on %s", other, i, host)
+				}
+				hosts[host] = i
+			})
+		}
+	})
+	require.Len(t, hosts, servers, "not all servers started on distinct ports")
+}
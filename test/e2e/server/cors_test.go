@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/rest"
+
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestCORSAllowedOrigins(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithCORSAllowedOrigins([]string{`//allowed\.example\.com$`}))
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	cfg := server.BaseConfig(t)
+	httpClient, err := rest.HTTPClientFor(cfg)
+	require.NoError(t, err)
+
+	allowOriginFor := func(origin string) string {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.Host+"/api", nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", origin)
+		resp, err := httpClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		return resp.Header.Get("Access-Control-Allow-Origin")
+	}
+
+	t.Logf("Requesting with an allowed origin")
+	require.Equal(t, "https://allowed.example.com", allowOriginFor("https://allowed.example.com"))
+
+	t.Logf("Requesting with a disallowed origin")
+	require.Empty(t, allowOriginFor("https://evil.example.com"))
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestDiscoveryCachingHeaders(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithDiscoveryCacheMaxAge(time.Minute))
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	cfg := server.BaseConfig(t)
+
+	for _, path := range []string{"/api", "/api/v1", "/apis", "/apis/apps/v1"} {
+		t.Run(path, func(t *testing.T) {
+			header := framework.AssertCachingHeaders(ctx, t, cfg, wsPath.RequestPath()+path)
+			require.Equal(t, "private, max-age=60", header.Get("Cache-Control"))
+		})
+	}
+}
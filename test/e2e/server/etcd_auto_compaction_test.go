@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestEtcdAutoCompaction(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t,
+		kcptestingserver.WithEtcdAutoCompaction("revision", "1"),
+		// only etcd compacts, and lists are served from etcd
+		kcptestingserver.WithEtcdCompactionInterval(0),
+		kcptestingserver.WithWatchCacheSizes(map[string]int{"configmaps": 0}),
+	)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+	configMaps := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("default")
+
+	cm, err := configMaps.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "compaction"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	oldRV := cm.ResourceVersion
+	for i := range 5 {
+		cm.Data = map[string]string{"generation": fmt.Sprint(i)}
+		cm, err = configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+		require.NoError(t, err)
+	}
+
+	listAtOldRV := metav1.ListOptions{ResourceVersion: oldRV, ResourceVersionMatch: metav1.ResourceVersionMatchExact}
+	_, err = configMaps.List(ctx, listAtOldRV)
+	require.NoError(t, err, "revision %s should still be available right after it was written", oldRV)
+
+	t.Logf("Waiting for revision %s to be compacted, revision mode compacts every five minutes", oldRV)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		_, err := configMaps.List(ctx, listAtOldRV)
+		if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+			return true, ""
+		}
+		if err != nil {
+			return false, fmt.Sprintf("unexpected error listing at revision %s: %v", oldRV, err)
+		}
+		return false, fmt.Sprintf("revision %s is still available", oldRV)
+	}, 10*time.Minute, time.Second, "revision %s was not compacted", oldRV)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestEtcdCompactionInterval(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	// Watches on configmaps bypass the watch cache, so that they are served
+	// by etcd and fail as soon as the revision is compacted.
+	server := kcptesting.PrivateKcpServer(t,
+		kcptestingserver.WithEtcdCompactionInterval(2*time.Second),
+		kcptestingserver.WithWatchCacheSizes(map[string]int{"configmaps": 0}),
+	)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+
+	_, err = kubeClusterClient.Cluster(wsPath).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "compaction"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	configMaps := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("compaction")
+
+	t.Logf("Creating a configmap to establish an old resourceVersion")
+	cm, err := configMaps.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "old"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	oldResourceVersion := cm.ResourceVersion
+
+	framework.AssertWatchExpires(ctx, t, dynamicClusterClient.Cluster(wsPath), corev1.SchemeGroupVersion.WithResource("configmaps"), oldResourceVersion)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestEtcdPrefixIsolation(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	etcdURL := framework.StartEtcd(t)
+	serverA := kcptesting.PrivateKcpServer(t,
+		kcptestingserver.WithCustomArguments("--etcd-servers="+etcdURL),
+		kcptestingserver.WithEtcdPrefix("/e2e-kcp-a"),
+	)
+	serverB := kcptesting.PrivateKcpServer(t,
+		kcptestingserver.WithCustomArguments("--etcd-servers="+etcdURL),
+		kcptestingserver.WithEtcdPrefix("/e2e-kcp-b"),
+	)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	clientA, err := kcpkubernetesclientset.NewForConfig(serverA.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kube cluster client for server A")
+	clientB, err := kcpkubernetesclientset.NewForConfig(serverB.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kube cluster client for server B")
+
+	t.Logf("Creating a ConfigMap on server A")
+	_, err = clientA.Cluster(core.RootCluster.Path()).CoreV1().ConfigMaps(metav1.NamespaceDefault).Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd-prefix-isolation"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create ConfigMap on server A")
+
+	t.Logf("Verifying the ConfigMap is not visible on server B")
+	_, err = clientB.Cluster(core.RootCluster.Path()).CoreV1().ConfigMaps(metav1.NamespaceDefault).Get(ctx, "etcd-prefix-isolation", metav1.GetOptions{})
+	require.True(t, apierrors.IsNotFound(err), "expected ConfigMap of server A to be not found on server B, got: %v", err)
+
+	t.Logf("Verifying the keys in etcd are stored below the prefix of each server")
+	etcdClient, err := clientv3.New(clientv3.Config{Endpoints: []string{etcdURL}, DialTimeout: 10 * time.Second})
+	require.NoError(t, err, "failed to construct etcd client")
+	t.Cleanup(func() { etcdClient.Close() })
+	keysBelow := func(prefix string) []string {
+		resp, err := etcdClient.Get(ctx, prefix+"/", clientv3.WithPrefix(), clientv3.WithKeysOnly())
+		require.NoError(t, err, "failed to list etcd keys below %s", prefix)
+		keys := make([]string, 0, len(resp.Kvs))
+		for _, kv := range resp.Kvs {
+			keys = append(keys, string(kv.Key))
+		}
+		return keys
+	}
+	containsConfigMap := func(keys []string) bool {
+		for _, key := range keys {
+			if strings.Contains(key, "/configmaps/") && strings.HasSuffix(key, "/etcd-prefix-isolation") {
+				return true
+			}
+		}
+		return false
+	}
+	keysA, keysB := keysBelow("/e2e-kcp-a"), keysBelow("/e2e-kcp-b")
+	require.NotEmpty(t, keysA, "server A stored no data below its prefix")
+	require.NotEmpty(t, keysB, "server B stored no data below its prefix")
+	require.True(t, containsConfigMap(keysA), "ConfigMap not stored below the prefix of server A")
+	require.False(t, containsConfigMap(keysB), "ConfigMap of server A stored below the prefix of server B")
+}
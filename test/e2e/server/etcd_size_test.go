@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestEtcdSizeStableUnderChurn(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t,
+		kcptestingserver.WithEtcdSizeSampling(time.Second),
+		kcptestingserver.WithEtcdCompactionInterval(5*time.Second),
+	)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+	configMaps := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps(metav1.NamespaceDefault)
+
+	// every round writes and deletes about 2MB
+	payload := strings.Repeat("x", 100<<10)
+	churn := func(rounds int) {
+		for round := range rounds {
+			for i := range 20 {
+				_, err := configMaps.Create(ctx, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("churn-%d", i)},
+					Data:       map[string]string{"payload": payload},
+				}, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create configmap in round %d", round)
+			}
+			for i := range 20 {
+				err := configMaps.Delete(ctx, fmt.Sprintf("churn-%d", i), metav1.DeleteOptions{})
+				require.NoError(t, err, "failed to delete configmap in round %d", round)
+			}
+		}
+	}
+
+	t.Logf("Warming up, so the database is grown to its working size")
+	churn(5)
+
+	since := time.Now()
+	t.Logf("Creating and deleting configmaps in a loop")
+	churn(30)
+
+	end := time.Now()
+	t.Logf("Waiting for the end of the loop to be sampled")
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		samples := kcptestingserver.EtcdSizeSamples(server)
+		if len(samples) == 0 {
+			return false, "no samples yet"
+		}
+		last := samples[len(samples)-1]
+		return last.Time.After(end), fmt.Sprintf("latest sample is from %s", last.Time)
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "etcd size was not sampled after the loop")
+
+	// without compaction, the loop grows the database by about 60MB
+	framework.AssertEtcdSizeStable(t, server, since, 24<<20)
+}
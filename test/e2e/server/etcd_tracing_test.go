@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestEtcdTracing(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	artifactDir, dataDir, err := kcptestingserver.ScratchDirs(t)
+	require.NoError(t, err)
+
+	server := kcptesting.PrivateKcpServer(t,
+		kcptestingserver.WithEtcdTracing(),
+		kcptestingserver.WithScratchDirectories(artifactDir, dataDir),
+	)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kube client for server")
+
+	t.Logf("Creating ConfigMaps and listing them uncached to hit etcd")
+	for i := range 20 {
+		_, err := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("default").Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("traced-%d", i)},
+			Data:       map[string]string{"data": strings.Repeat("x", 64*1024)},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err, "failed to create ConfigMap")
+	}
+	// resourceVersion "" is served from etcd, not the watch cache.
+	_, err = kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err, "failed to list ConfigMaps")
+
+	tracesPath := filepath.Join(artifactDir, "kcp", "main", "traces.jsonl")
+	t.Logf("Waiting for etcd spans in %s", tracesPath)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		data, err := os.ReadFile(tracesPath)
+		if err != nil {
+			return false, fmt.Sprintf("failed to read traces: %v", err)
+		}
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			var span struct {
+				Name string `json:"name"`
+			}
+			if err := json.Unmarshal([]byte(line), &span); err != nil {
+				return false, fmt.Sprintf("failed to parse span %q: %v", line, err)
+			}
+			if strings.HasPrefix(span.Name, "etcdserverpb.KV/") {
+				return true, ""
+			}
+		}
+		return false, "no etcd span recorded yet"
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "no etcd request was traced")
+}
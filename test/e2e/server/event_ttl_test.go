@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/tools/reference"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestEventTTL(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithEventTTL(5*time.Minute))
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+
+	cm, err := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps(metav1.NamespaceDefault).Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "eventful"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	ref, err := reference.GetReference(scheme.Scheme, cm)
+	require.NoError(t, err)
+
+	t.Logf("Recording a warning about the configmap like a failing controller would")
+	broadcaster := record.NewBroadcaster()
+	t.Cleanup(broadcaster.Shutdown)
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClusterClient.Cluster(wsPath).CoreV1().Events("")})
+	recorder := broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "e2e-controller"})
+	recorder.Event(cm, corev1.EventTypeWarning, "ReconcileFailed", "failed to reconcile")
+
+	event := framework.WaitForEvent(ctx, t, kubeClusterClient, wsPath, metav1.NamespaceDefault, *ref, "ReconcileFailed")
+	require.Equal(t, corev1.EventTypeWarning, event.Type)
+	require.Equal(t, "e2e-controller", event.Source.Component)
+	require.Equal(t, "failed to reconcile", event.Message)
+}
+
+func TestEventTTLExpiry(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	const ttl = 10 * time.Second
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithEventTTL(ttl))
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+
+	t.Logf("Creating an event with a TTL of %s", ttl)
+	events := kubeClusterClient.Cluster(wsPath).CoreV1().Events(metav1.NamespaceDefault)
+	event, err := events.Create(ctx, &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{GenerateName: "expiring-"},
+		InvolvedObject: corev1.ObjectReference{Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "eventful"},
+		Reason:         "Expiring",
+		Type:           corev1.EventTypeNormal,
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	elapsed := framework.AssertExpiresWithin(ctx, t, func(ctx context.Context) error {
+		_, err := events.Get(ctx, event.Name, metav1.GetOptions{})
+		return err
+	}, ttl+30*time.Second)
+	require.GreaterOrEqual(t, elapsed, ttl-2*time.Second, "event expired before its TTL")
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	apisv1alpha2 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestExternalURL(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t,
+		kcptestingserver.WithExternalURLScheme("http"),
+		kcptestingserver.WithExternalURLPathPrefix("/kcp"),
+	)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	serverURL, err := url.Parse(server.BaseConfig(t).Host)
+	require.NoError(t, err)
+	externalPrefix := "http://localhost:" + serverURL.Port() + "/kcp/"
+
+	wsPath, ws := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	t.Logf("Checking the workspace URL %s uses the external URL", ws.Spec.URL)
+	require.True(t, strings.HasPrefix(ws.Spec.URL, externalPrefix+"clusters/"), "workspace URL %s does not start with %s", ws.Spec.URL, externalPrefix)
+
+	t.Logf("Creating APIExport in %s", wsPath)
+	_, err = kcpClusterClient.Cluster(wsPath).ApisV1alpha2().APIExports().Create(ctx, &apisv1alpha2.APIExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "external-url"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		export, err := kcpClusterClient.Cluster(wsPath).ApisV1alpha2().APIExports().Get(ctx, "external-url", metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Sprintf("failed to get APIExport: %v", err)
+		}
+		urls := framework.ExportVirtualWorkspaceURLs(export)
+		if len(urls) == 0 {
+			return false, "APIExport has no virtual workspace URLs yet"
+		}
+		for _, u := range urls {
+			if !strings.HasPrefix(u, externalPrefix+"services/apiexport/") {
+				return false, fmt.Sprintf("virtual workspace URL %s does not start with %s", u, externalPrefix)
+			}
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "APIExport virtual workspace URLs do not use the external URL")
+}
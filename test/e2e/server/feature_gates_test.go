@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kcpfeatures "github.com/kcp-dev/kcp/pkg/features"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestEffectiveFeatureGates(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	// WorkspaceMounts is off by default, enabling it must show up.
+	server := kcptesting.PrivateKcpServer(t,
+		kcptestingserver.WithCustomArguments("--feature-gates="+string(kcpfeatures.WorkspaceMounts)+"=true"),
+	)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	gates, err := kcptestingserver.EffectiveFeatureGates(ctx, server)
+	require.NoError(t, err, "failed to get effective feature gates")
+	require.Contains(t, gates, string(kcpfeatures.WorkspaceMounts), "feature gate is not reported by the server")
+	require.True(t, gates[string(kcpfeatures.WorkspaceMounts)], "feature gate was not enabled")
+}
+
+func TestWithFeatureGates(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t,
+		kcptestingserver.WithFeatureGates(map[string]bool{string(kcpfeatures.WorkspaceMounts): true}),
+	)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	gates, err := kcptestingserver.EffectiveFeatureGates(ctx, server)
+	require.NoError(t, err, "failed to get effective feature gates")
+	require.True(t, gates[string(kcpfeatures.WorkspaceMounts)], "feature gate was not enabled")
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kubernetesclientset "k8s.io/client-go/kubernetes"
+
+	kcpclienthelper "github.com/kcp-dev/apimachinery/v2/pkg/client"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestPriorityAndFairnessClassification(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	// priority-and-fairness is enabled by default. kcp does not serve the
+	// flowcontrol.apiserver.k8s.io API, so requests are classified into the
+	// mandatory "exempt" and "catch-all" flow schemas only.
+	server := kcptesting.PrivateKcpServer(t,
+		kcptestingserver.WithCustomArguments("--token-auth-file", framework.DefaultTokenAuthFile),
+	)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	userCfg := kcpclienthelper.SetCluster(framework.StaticTokenUserConfig("user-1", server.BaseConfig(t)), core.RootCluster.Path())
+	userClient, err := kubernetesclientset.NewForConfig(userCfg)
+	require.NoError(t, err, "failed to construct client for user-1")
+
+	t.Logf("Issuing requests as a regular user")
+	for i := 0; i < 10; i++ {
+		// the result does not matter, the request is classified before authorization
+		_ = userClient.CoreV1().RESTClient().Get().Resource("namespaces").Do(ctx).Error()
+	}
+
+	rootShardCfg := server.RootShardSystemMasterBaseConfig(t)
+	dispatched, _ := framework.AssertRequestQueued(ctx, t, rootShardCfg, "catch-all")
+	require.GreaterOrEqual(t, dispatched, 10.0, "expected the user requests to go through the catch-all flow schema")
+	framework.AssertRequestQueued(ctx, t, rootShardCfg, "exempt")
+}
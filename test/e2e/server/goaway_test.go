@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptrace"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/rest"
+
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestGoawayChance(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithGoawayChance(0.02))
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	cfg := server.RootShardSystemMasterBaseConfig(t)
+	client, err := rest.HTTPClientFor(cfg)
+	require.NoError(t, err)
+
+	var newConns atomic.Int32
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if !info.Reused {
+				newConns.Add(1)
+			}
+		},
+	}
+	traceCtx := httptrace.WithClientTrace(ctx, trace)
+
+	// With one in 50 requests getting a GOAWAY, 500 requests are all but
+	// guaranteed to trigger some.
+	const requests = 500
+	t.Logf("Sending %d requests to %s", requests, cfg.Host)
+	for i := range requests {
+		req, err := http.NewRequestWithContext(traceCtx, http.MethodGet, cfg.Host+"/version", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err, "request %d failed", i)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode, "request %d failed", i)
+		require.Equal(t, 2, resp.ProtoMajor, "request %d did not use HTTP/2", i)
+	}
+
+	t.Logf("Client opened %d connections", newConns.Load())
+	require.Greater(t, newConns.Load(), int32(1), "expected GOAWAYs to make the client reconnect")
+}
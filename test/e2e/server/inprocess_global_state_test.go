@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	genericfeatures "k8s.io/apiserver/pkg/features"
+
+	kcpfeatures "github.com/kcp-dev/kcp/pkg/features"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// TestInProcessResetGlobalState is not parallel because in-process servers of
+// other tests would see the feature gates of this test, and vice versa.
+func TestInProcessResetGlobalState(t *testing.T) {
+	framework.Suite(t, "control-plane")
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	verbosity := flag.Lookup("v").Value.String()
+	t.Run("first server enables WorkspaceMounts", func(t *testing.T) {
+		server := kcptesting.PrivateKcpServer(t,
+			kcptestingserver.WithRunInProcess(),
+			kcptestingserver.WithCustomArguments("--feature-gates="+string(kcpfeatures.WorkspaceMounts)+"=true"),
+		)
+		require.NoError(t, flag.Lookup("v").Value.Set("9"), "failed to raise klog verbosity")
+
+		gates, err := kcptestingserver.EffectiveFeatureGates(ctx, server)
+		require.NoError(t, err, "failed to get effective feature gates")
+		require.True(t, gates[string(kcpfeatures.WorkspaceMounts)], "WorkspaceMounts was not enabled")
+		require.True(t, gates[string(genericfeatures.APIResponseCompression)], "APIResponseCompression was not enabled by default")
+	})
+	require.False(t, kcpfeatures.DefaultFeatureGate.Enabled(kcpfeatures.WorkspaceMounts), "WorkspaceMounts was not restored")
+	require.Equal(t, verbosity, flag.Lookup("v").Value.String(), "klog verbosity was not restored")
+
+	t.Run("second server disables APIResponseCompression", func(t *testing.T) {
+		server := kcptesting.PrivateKcpServer(t,
+			kcptestingserver.WithRunInProcess(),
+			kcptestingserver.WithCustomArguments("--feature-gates="+string(genericfeatures.APIResponseCompression)+"=false"),
+		)
+
+		gates, err := kcptestingserver.EffectiveFeatureGates(ctx, server)
+		require.NoError(t, err, "failed to get effective feature gates")
+		require.False(t, gates[string(kcpfeatures.WorkspaceMounts)], "WorkspaceMounts bled through from the first server")
+		require.False(t, gates[string(genericfeatures.APIResponseCompression)], "APIResponseCompression was not disabled")
+	})
+	require.True(t, kcpfeatures.DefaultFeatureGate.Enabled(genericfeatures.APIResponseCompression), "APIResponseCompression was not restored")
+}
+
+// TestInProcessFeatureGatesRestoredAfterFailedStart is not parallel because
+// in-process servers of other tests would see the feature gates of this test,
+// and vice versa.
+func TestInProcessFeatureGatesRestoredAfterFailedStart(t *testing.T) {
+	framework.Suite(t, "control-plane")
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	t.Run("server fails validation after parsing WorkspaceMounts", func(t *testing.T) {
+		_, err := kcptestingserver.ContextRunInProcessFunc(ctx, t, kcptestingserver.Config{
+			DataDir: t.TempDir(),
+			Args: []string{
+				"--feature-gates=" + string(kcpfeatures.WorkspaceMounts) + "=true",
+				"--secure-port=-1",
+			},
+		})
+		require.Error(t, err, "in-process kcp started with an invalid secure port")
+		require.True(t, kcpfeatures.DefaultFeatureGate.Enabled(kcpfeatures.WorkspaceMounts), "WorkspaceMounts was not parsed before the failure")
+	})
+	require.False(t, kcpfeatures.DefaultFeatureGate.Enabled(kcpfeatures.WorkspaceMounts), "WorkspaceMounts was not restored after the failed start")
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// lockedBuffer is a bytes.Buffer safe for concurrent use.
+type lockedBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.String()
+}
+
+func TestInProcessLogWriter(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	names := []string{"first", "second"}
+	logs := map[string]*lockedBuffer{}
+	dataDirs := map[string]string{}
+	var lock sync.Mutex
+
+	t.Run("servers", func(t *testing.T) {
+		for _, name := range names {
+			buf := &lockedBuffer{}
+			logs[name] = buf
+
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				artifactDir, dataDir, err := kcptestingserver.ScratchDirs(t)
+				require.NoError(t, err)
+				lock.Lock()
+				dataDirs[name] = dataDir
+				lock.Unlock()
+
+				kcptesting.PrivateKcpServer(t,
+					kcptestingserver.WithRunInProcess(),
+					kcptestingserver.WithScratchDirectories(artifactDir, dataDir),
+					kcptestingserver.WithLogWriter(buf),
+				)
+			})
+		}
+	})
+
+	for _, name := range names {
+		captured := logs[name].String()
+		require.Contains(t, captured, "dataDir=\""+dataDirs[name]+"\"", "%s server did not log its own startup", name)
+		require.Contains(t, captured, "Creating or updating Shard", "%s server did not log its shard bootstrap", name)
+		for _, other := range names {
+			if other == name {
+				continue
+			}
+			require.False(t, strings.Contains(captured, dataDirs[other]), "%s server captured logs of the %s server", name, other)
+		}
+	}
+}
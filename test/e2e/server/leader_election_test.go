@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestControllersLeaderElection(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithCustomArguments(
+		"--enable-leader-election",
+		"--leader-election-name=e2e-kcp-controllers",
+	))
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.RootShardSystemMasterBaseConfig(t))
+	require.NoError(t, err, "failed to construct kube cluster client for root shard")
+	// the controllers elect their leader in the shard-local admin cluster
+	client := kubeClusterClient.Cluster(logicalcluster.NewPath("system:admin"))
+
+	leader := framework.AssertSingleLeader(ctx, t, client, metav1.NamespaceSystem, "e2e-kcp-controllers")
+	t.Logf("Controllers are led by %s", leader)
+
+	newLeader := framework.ForceLeaderFailover(ctx, t, client, metav1.NamespaceSystem, "e2e-kcp-controllers")
+	require.Equal(t, leader, newLeader, "the only candidate is expected to acquire the Lease again")
+	require.Equal(t, newLeader, framework.AssertSingleLeader(ctx, t, client, metav1.NamespaceSystem, "e2e-kcp-controllers"))
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestStorageMediaTypeMatrix(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	framework.Matrix(t, []framework.MatrixCase{
+		{Name: "json", Options: []kcptestingserver.Option{kcptestingserver.WithCustomArguments("--storage-media-type=application/json")}},
+		{Name: "protobuf", Options: []kcptestingserver.Option{kcptestingserver.WithCustomArguments("--storage-media-type=application/vnd.kubernetes.protobuf")}},
+	}, func(t *testing.T, clients framework.MatrixClients) {
+		ctx, cancelFunc := context.WithCancel(context.Background())
+		t.Cleanup(cancelFunc)
+
+		wsPath, _ := kcptesting.NewWorkspaceFixture(t, clients.Server, core.RootCluster.Path())
+
+		configMaps := clients.KubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps(metav1.NamespaceDefault)
+		_, err := configMaps.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "matrix"},
+			Data:       map[string]string{"stored": "true"},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		cm, err := configMaps.Get(ctx, "matrix", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"stored": "true"}, cm.Data)
+	})
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestNoServerErrors(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+
+	metricsCfg := server.RootShardSystemMasterBaseConfig(t)
+	before := framework.GetMetricsSnapshot(ctx, t, metricsCfg)
+
+	t.Logf("Creating, listing and deleting configmaps in %s", wsPath)
+	_, err = kubeClusterClient.Cluster(wsPath).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "metrics"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	configMaps := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("metrics")
+	for i := range 10 {
+		_, err := configMaps.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("cm-%d", i)}}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	_, err = configMaps.List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.NoError(t, configMaps.DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{}))
+
+	after := framework.GetMetricsSnapshot(ctx, t, metricsCfg)
+	require.Greater(t, after.Sum("apiserver_request_total", map[string]string{"resource": "configmaps"}), before.Sum("apiserver_request_total", map[string]string{"resource": "configmaps"}), "requests were not counted")
+	framework.AssertNoMetricIncrease(t, before, after, "apiserver_request_total", map[string]string{"code": "5.."})
+}
+
+func TestWaitForControllerMetric(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	t.Logf("Creating a workspace to give the controllers some work")
+	kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+
+	metrics := framework.WaitForMetric(ctx, t, server.RootShardSystemMasterBaseConfig(t), "workqueue_adds_total")
+	require.Positive(t, metrics.Sum("workqueue_adds_total", nil), "no items were added to any workqueue")
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/rest"
+
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// TestFixtureMixedShards starts a sharded and a single-shard server in one
+// fixture and checks that the readiness hook of the single-shard server runs
+// against that server.
+func TestFixtureMixedShards(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	var lock sync.Mutex
+	var hosts []string
+	hook := func(ctx context.Context, cfg *rest.Config) error {
+		lock.Lock()
+		defer lock.Unlock()
+		hosts = append(hosts, cfg.Host)
+		return nil
+	}
+
+	artifactDir, dataDir, err := kcptestingserver.ScratchDirs(t)
+	require.NoError(t, err, "failed to create scratch dirs")
+	sharded := kcptestingserver.Config{Name: "sharded"}
+	kcptestingserver.WithScratchDirectories(artifactDir, dataDir)(&sharded)
+	kcptestingserver.WithShards(2)(&sharded)
+	plain := kcptestingserver.Config{Name: "plain"}
+	kcptestingserver.WithScratchDirectories(artifactDir, dataDir)(&plain)
+	kcptestingserver.WithReadinessHook(hook)(&plain)
+
+	f := kcptestingserver.NewFixture(t, sharded, plain)
+
+	require.Len(t, f["sharded"].ShardNames(), 2)
+	require.Equal(t, []string{f["plain"].RootShardSystemMasterBaseConfig(t).Host}, hosts, "readiness hook should run once against the single-shard server")
+}
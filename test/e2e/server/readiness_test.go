@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+
+	kcpclienthelper "github.com/kcp-dev/apimachinery/v2/pkg/client"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	tenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestReadinessHook(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	var calls []string
+	groupInDiscovery := func(ctx context.Context, cfg *rest.Config) error {
+		calls = append(calls, "discovery")
+		client, err := discovery.NewDiscoveryClientForConfig(kcpclienthelper.SetCluster(cfg, core.RootCluster.Path()))
+		if err != nil {
+			return err
+		}
+		return wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, wait.ForeverTestTimeout, true, func(ctx context.Context) (bool, error) {
+			groups, err := client.ServerGroups()
+			if err != nil {
+				return false, nil //nolint:nilerr // retry until the group shows up
+			}
+			for _, g := range groups.Groups {
+				if g.Name == tenancyv1alpha1.SchemeGroupVersion.Group {
+					return true, nil
+				}
+			}
+			return false, nil
+		})
+	}
+	second := func(ctx context.Context, cfg *rest.Config) error {
+		calls = append(calls, "second")
+		return nil
+	}
+
+	server := kcptesting.PrivateKcpServer(t,
+		kcptestingserver.WithReadinessHook(groupInDiscovery),
+		kcptestingserver.WithReadinessHook(second),
+	)
+
+	require.Equal(t, []string{"discovery", "second"}, calls, "readiness hooks should run in order before the fixture is returned")
+	require.NotEmpty(t, server.BaseConfig(t).Host)
+}
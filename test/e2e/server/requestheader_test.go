@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/sdk/testing/third_party/library-go/crypto"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestRequestHeaderAllowedNames(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	dir := t.TempDir()
+	path := func(name string) string {
+		return filepath.Join(dir, name)
+	}
+	requestHeaderCA, err := crypto.MakeSelfSignedCA(path("requestheader-ca.crt"), path("requestheader-ca.key"), path("requestheader-ca-serial.txt"), "e2e-requestheader-ca", 365)
+	require.NoError(t, err, "failed to create requestheader CA")
+	for _, name := range []string{"allowed-proxy", "other-proxy"} {
+		_, err := requestHeaderCA.MakeClientCertificate(path(name+".crt"), path(name+".key"), &user.DefaultInfo{Name: name}, 365)
+		require.NoError(t, err, "failed to create client certificate for %s", name)
+	}
+
+	server := kcptesting.PrivateKcpServer(t,
+		kcptestingserver.WithCustomArguments(
+			"--requestheader-client-ca-file="+path("requestheader-ca.crt"),
+			"--requestheader-username-headers=X-Remote-User",
+			"--requestheader-group-headers=X-Remote-Group",
+		),
+		kcptestingserver.WithRequestHeaderAllowedNames([]string{"allowed-proxy"}),
+	)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	const asserted = "requestheader-user"
+	reviewAs := func(proxy string) (*authenticationv1.SelfSubjectReview, error) {
+		base := server.BaseConfig(t)
+		cfg := &rest.Config{
+			Host: base.Host,
+			TLSClientConfig: rest.TLSClientConfig{
+				CAData:   base.CAData,
+				CertFile: path(proxy + ".crt"),
+				KeyFile:  path(proxy + ".key"),
+			},
+			WrapTransport: func(rt http.RoundTripper) http.RoundTripper {
+				return transport.NewAuthProxyRoundTripper(asserted, "", []string{"system:authenticated"}, nil, rt)
+			},
+		}
+		kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(cfg)
+		require.NoError(t, err, "failed to construct kube cluster client for %s", proxy)
+		return kubeClusterClient.Cluster(core.RootCluster.Path()).AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	}
+
+	t.Logf("Verifying a proxy in the allowed names can assert a user")
+	review, err := reviewAs("allowed-proxy")
+	require.NoError(t, err, "failed to review identity through allowed-proxy")
+	require.Equal(t, asserted, review.Status.UserInfo.Username)
+
+	t.Logf("Verifying a proxy not in the allowed names cannot assert a user")
+	review, err = reviewAs("other-proxy")
+	if err == nil {
+		require.NotEqual(t, asserted, review.Status.UserInfo.Username, "other-proxy must not be trusted to assert users")
+	} else {
+		t.Logf("Request through other-proxy failed as expected: %v", err)
+	}
+}
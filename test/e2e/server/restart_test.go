@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// TestRestart restarts a private server and checks that it keeps its data and
+// that clients created before the restart keep working.
+func TestRestart(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t)
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	cfg := server.BaseConfig(t)
+	client, err := kcpkubernetesclientset.NewForConfig(cfg)
+	require.NoError(t, err)
+
+	t.Logf("Creating a ConfigMap before the restart")
+	configMaps := client.Cluster(core.RootCluster.Path()).CoreV1().ConfigMaps(metav1.NamespaceDefault)
+	cm, err := configMaps.Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "restart-"},
+		Data:       map[string]string{"foo": "bar"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, kcptestingserver.Restart(t, server), "failed to restart server")
+	require.False(t, server.Stopped(), "server reports stopped after restart")
+	require.Equal(t, cfg.Host, server.BaseConfig(t).Host, "server address changed on restart")
+
+	t.Logf("Verifying the ConfigMap survived the restart")
+	got, err := configMaps.Get(ctx, cm.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, cm.Data, got.Data)
+}
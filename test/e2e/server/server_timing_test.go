@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestServerTiming(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithServerTiming())
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+
+	t.Logf("Creating a ConfigMap in %s", wsPath)
+	trace := framework.RequestTrace(ctx, t, server.BaseConfig(t), func(ctx context.Context, cfg *rest.Config) error {
+		client, err := kcpkubernetesclientset.NewForConfig(cfg)
+		if err != nil {
+			return err
+		}
+		_, err = client.Cluster(wsPath).CoreV1().ConfigMaps("default").Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "traced"},
+		}, metav1.CreateOptions{})
+		return err
+	})
+	require.Len(t, trace.Requests, 1, "expected the create to be traced")
+
+	storage, found := trace.Phase("storage")
+	require.True(t, found, "expected the trace to include a storage phase")
+	total, found := trace.Phase("total")
+	require.True(t, found, "expected the trace to include the total")
+	require.Positive(t, storage)
+	require.LessOrEqual(t, storage, total, "storage took longer than the whole request")
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// TestShardPartition cuts a non-root shard off the network and checks that the
+// root shard keeps serving. It uses a private server, so the partition does not
+// affect other tests.
+func TestShardPartition(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithShards(2))
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	const shardName = "shard-1"
+	rootShardClient, err := kcpclientset.NewForConfig(server.RootShardSystemMasterBaseConfig(t))
+	require.NoError(t, err, "failed to construct kcp client for root shard")
+	shardClient, err := kcpclientset.NewForConfig(server.ShardSystemMasterBaseConfig(t, shardName))
+	require.NoError(t, err, "failed to construct kcp client for shard %s", shardName)
+
+	framework.PartitionShard(t, server, shardName)
+
+	t.Logf("Verifying shard %s is unreachable", shardName)
+	shortCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	_, err = shardClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().LogicalClusters().List(shortCtx, metav1.ListOptions{})
+	require.Error(t, err, "partitioned shard %s is still reachable", shardName)
+
+	t.Logf("Verifying the root shard still serves requests")
+	for range 5 {
+		_, err := rootShardClient.Cluster(core.RootCluster.Path()).TenancyV1alpha1().Workspaces().List(ctx, metav1.ListOptions{})
+		require.NoError(t, err, "root shard failed to serve while shard %s is partitioned", shardName)
+		time.Sleep(time.Second)
+	}
+
+	framework.HealShard(t, server, shardName)
+
+	t.Logf("Waiting for shard %s to be reachable again", shardName)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		_, err := shardClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().LogicalClusters().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return false, fmt.Sprintf("shard is unreachable: %v", err)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "shard %s did not recover from the partition", shardName)
+}
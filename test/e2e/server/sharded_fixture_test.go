@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	corev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// TestWithShards starts a private server with two shards and checks that a
+// workspace scheduled on the non-root shard is served by that shard, also after
+// the shards were restarted.
+func TestWithShards(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithShards(2))
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	require.Equal(t, []string{corev1alpha1.RootShard, "shard-1"}, server.ShardNames())
+
+	kcpClusterClient, err := kcpclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+	shards, err := kcpClusterClient.Cluster(core.RootCluster.Path()).CoreV1alpha1().Shards().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, shards.Items, 2, "expected a Shard object per shard")
+
+	t.Logf("Creating a workspace on shard-1")
+	_, ws := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path(), kcptesting.WithShard("shard-1"))
+	clusterName := logicalcluster.Name(ws.Spec.Cluster)
+
+	t.Logf("Verifying logical cluster %s is served by shard-1", clusterName)
+	shardClient, err := kcpclientset.NewForConfig(server.ShardSystemMasterBaseConfig(t, "shard-1"))
+	require.NoError(t, err)
+	_, err = shardClient.Cluster(clusterName.Path()).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+	require.NoError(t, err, "logical cluster %s not found on shard-1", clusterName)
+
+	rootShardClient, err := kcpclientset.NewForConfig(server.RootShardSystemMasterBaseConfig(t))
+	require.NoError(t, err)
+	_, err = rootShardClient.Cluster(clusterName.Path()).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+	require.Error(t, err, "logical cluster %s unexpectedly found on the root shard", clusterName)
+
+	t.Logf("Restarting all shards")
+	require.NoError(t, kcptestingserver.Restart(t, server), "failed to restart shards")
+	_, err = shardClient.Cluster(clusterName.Path()).CoreV1alpha1().LogicalClusters().Get(ctx, corev1alpha1.LogicalClusterName, metav1.GetOptions{})
+	require.NoError(t, err, "logical cluster %s not found on shard-1 after restart", clusterName)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/rest"
+
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// TestShutdownDelay checks that during the shutdown delay /readyz fails while
+// requests are still served. The delay is long enough for this to be observed
+// reliably, the short window of 429 responses after the delay is not asserted.
+func TestShutdownDelay(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	const shutdownDelay = 20 * time.Second
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithShutdownDelay(shutdownDelay, true))
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	cfg := server.RootShardSystemMasterBaseConfig(t)
+	httpClient, err := rest.HTTPClientFor(cfg)
+	require.NoError(t, err)
+	get := func(path string) (int, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.Host+path, nil)
+		if err != nil {
+			return 0, err
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	code, err := get("/readyz")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code, "server is not ready before shutdown")
+
+	t.Logf("Initiating shutdown")
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		server.Stop()
+	}()
+
+	t.Logf("Waiting for /readyz to fail during the shutdown delay")
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		code, err := get("/readyz")
+		if err != nil {
+			return false, err.Error()
+		}
+		return code != http.StatusOK, fmt.Sprintf("/readyz returned %d", code)
+	}, shutdownDelay/2, 100*time.Millisecond, "/readyz did not fail after shutdown was initiated")
+
+	t.Logf("Checking requests are still served during the shutdown delay")
+	code, err = get("/api")
+	require.NoError(t, err, "server stopped serving during the shutdown delay")
+	require.Equal(t, http.StatusOK, code, "request during the shutdown delay failed")
+
+	<-stopped
+}
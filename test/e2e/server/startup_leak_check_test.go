@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/rest"
+
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+// leakRecordingT records the goroutine leaks reported by the fixture instead of
+// failing the test.
+type leakRecordingT struct {
+	*testing.T
+
+	lock  sync.Mutex
+	leaks []string
+}
+
+func (t *leakRecordingT) Errorf(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if !strings.Contains(msg, "leaked goroutines") {
+		t.T.Errorf(format, args...)
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.leaks = append(t.leaks, msg)
+}
+
+func (t *leakRecordingT) recordedLeaks() []string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.leaks
+}
+
+// TestStartupLeakCheck is not parallel because the leak check would report the
+// goroutines of other tests too.
+func TestStartupLeakCheck(t *testing.T) {
+	framework.Suite(t, "control-plane")
+
+	stop := make(chan struct{})
+	t.Cleanup(func() { close(stop) })
+	leakyHook := func(ctx context.Context, cfg *rest.Config) error {
+		go func() {
+			<-stop
+		}()
+		return nil
+	}
+
+	var recorder *leakRecordingT
+	t.Run("leaky hook", func(t *testing.T) {
+		recorder = &leakRecordingT{T: t}
+		kcptesting.PrivateKcpServer(recorder,
+			kcptestingserver.WithStartupLeakCheck(),
+			kcptestingserver.WithReadinessHook(leakyHook),
+		)
+	})
+
+	leaks := recorder.recordedLeaks()
+	require.Len(t, leaks, 1, "expected the goroutine leaked by the readiness hook to be reported")
+	t.Logf("Reported leak: %s", leaks[0])
+	require.Contains(t, leaks[0], "TestStartupLeakCheck", "expected the readiness hook to be reported as the origin of the leak")
+}
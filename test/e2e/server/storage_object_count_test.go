@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestStorageObjectCount(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithEtcdCountMetricPollPeriod(time.Second))
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+	shardKubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.RootShardSystemMasterBaseConfig(t))
+	require.NoError(t, err)
+
+	namespace, err := kubeClusterClient.Cluster(wsPath).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "bulk-"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	configMaps := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps(namespace.Name)
+
+	t.Logf("Waiting for the root CA configmap to be published, so it does not change the count later")
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		_, err := configMaps.Get(ctx, "kube-root-ca.crt", metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return false, "kube-root-ca.crt not found"
+		}
+		return err == nil, fmt.Sprintf("failed to get kube-root-ca.crt: %v", err)
+	}, wait.ForeverTestTimeout, 100*time.Millisecond)
+
+	const count = 100
+	t.Logf("Creating %d configmaps in %s|%s", count, wsPath, namespace.Name)
+	for i := range count {
+		_, err := configMaps.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("bulk-%d", i)},
+			Data:       map[string]string{"index": fmt.Sprint(i)},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	list, err := configMaps.List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, list.Items, count+1)
+
+	t.Logf("Checking that the storage count matches the configmaps of all logical clusters")
+	all, err := shardKubeClusterClient.CoreV1().ConfigMaps().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	framework.WaitForStorageObjectCount(ctx, t, server.RootShardSystemMasterBaseConfig(t), corev1.SchemeGroupVersion.WithResource("configmaps").GroupResource(), int64(len(all.Items)))
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestTLSMinVersion(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithTLSConfig("VersionTLS13", nil))
+
+	cfg := server.BaseConfig(t)
+	u, err := url.Parse(cfg.Host)
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(cfg.CAData), "failed to load CA of the server")
+
+	t.Logf("Verifying a TLS 1.2 client is rejected by %s", u.Host)
+	_, err = tls.Dial("tcp", u.Host, &tls.Config{
+		RootCAs:    roots,
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS12,
+	})
+	require.Error(t, err, "expected the TLS 1.2 handshake to fail")
+	t.Logf("TLS 1.2 handshake failed as expected: %v", err)
+
+	t.Logf("Verifying a TLS 1.3 client is accepted by %s", u.Host)
+	conn, err := tls.Dial("tcp", u.Host, &tls.Config{
+		RootCAs:    roots,
+		MinVersion: tls.VersionTLS13,
+	})
+	require.NoError(t, err, "expected the TLS 1.3 handshake to succeed")
+	defer conn.Close()
+	require.Equal(t, uint16(tls.VersionTLS13), conn.ConnectionState().Version)
+}
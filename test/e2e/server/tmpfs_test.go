@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"os"
+	goruntime "runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestRootDirectoryOnTmpfs(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithRootDirectoryOnTmpfs())
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	// The data directory holds the CA, so CADirectory reveals where it is.
+	dataDir := server.CADirectory()
+	t.Logf("Server data directory is %s", dataDir)
+	if info, err := os.Stat("/dev/shm"); goruntime.GOOS == "linux" && err == nil && info.IsDir() {
+		require.True(t, strings.HasPrefix(dataDir, "/dev/shm/"), "data dir %s is not on tmpfs", dataDir)
+	}
+
+	orgPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, orgPath)
+
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err, "failed to construct kube client for server")
+
+	t.Logf("Writing a ConfigMap to the tmpfs backed etcd")
+	_, err = kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("default").Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tmpfs"},
+		Data:       map[string]string{"key": "value"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create ConfigMap")
+
+	cm, err := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("default").Get(ctx, "tmpfs", metav1.GetOptions{})
+	require.NoError(t, err, "failed to get ConfigMap")
+	require.Equal(t, "value", cm.Data["key"])
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	kcpdynamic "github.com/kcp-dev/client-go/dynamic"
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestWatchExpiresAndResyncs(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t,
+		kcptestingserver.WithEtcdCompactionInterval(2*time.Second),
+		kcptestingserver.WithWatchCacheSizes(map[string]int{"configmaps": 0}),
+	)
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+	dynamicClusterClient, err := kcpdynamic.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+
+	_, err = kubeClusterClient.Cluster(wsPath).CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "resync"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	configMaps := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("resync")
+
+	cm, err := configMaps.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "old"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	gvr := corev1.SchemeGroupVersion.WithResource("configmaps")
+	framework.AssertWatchExpires(ctx, t, dynamicClusterClient.Cluster(wsPath), gvr, cm.ResourceVersion)
+
+	t.Logf("Starting a reflector after the resourceVersion expired")
+	store := framework.StartReflector(ctx, t, dynamicClusterClient.Cluster(wsPath), gvr)
+	_, exists, err := store.GetByKey("resync/old")
+	require.NoError(t, err)
+	require.True(t, exists, "reflector did not list the existing configmap")
+
+	t.Logf("Updating the configmap and waiting for the reflector to observe it")
+	cm.Data = map[string]string{"resynced": "true"}
+	_, err = configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		obj, exists, err := store.GetByKey("resync/old")
+		if err != nil || !exists {
+			return false, fmt.Sprintf("configmap not in store: exists=%v, err=%v", exists, err)
+		}
+		data, _, _ := unstructured.NestedStringMap(obj.(*unstructured.Unstructured).Object, "data")
+		return data["resynced"] == "true", fmt.Sprintf("configmap data is %v", data)
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "reflector did not observe the update")
+}
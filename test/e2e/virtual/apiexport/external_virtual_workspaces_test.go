@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	apisv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	apisv1alpha2 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha2"
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcpclientset "github.com/kcp-dev/kcp/sdk/client/clientset/versioned/cluster"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestinghelpers "github.com/kcp-dev/kcp/sdk/testing/helpers"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestExternalVirtualWorkspaces(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithExternalVirtualWorkspaces())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	cfg := server.BaseConfig(t)
+	kcpClusterClient, err := kcpclientset.NewForConfig(cfg)
+	require.NoError(t, err, "failed to construct kcp cluster client for server")
+
+	// Workspace initialization goes through the initializing workspaces
+	// virtual workspace, so this already depends on the external server.
+	providerPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	consumerPath, consumerWorkspace := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path())
+	consumerClusterName := logicalcluster.Name(consumerWorkspace.Spec.Cluster)
+
+	t.Logf("Creating APIExport in %s", providerPath)
+	_, err = kcpClusterClient.Cluster(providerPath).ApisV1alpha2().APIExports().Create(ctx, &apisv1alpha2.APIExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "external-vw"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	t.Logf("Binding APIExport in %s", consumerPath)
+	_, err = kcpClusterClient.Cluster(consumerPath).ApisV1alpha1().APIBindings().Create(ctx, &apisv1alpha1.APIBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "external-vw"},
+		Spec: apisv1alpha1.APIBindingSpec{
+			Reference: apisv1alpha1.BindingReference{
+				Export: &apisv1alpha1.ExportBindingReference{
+					Path: providerPath.String(),
+					Name: "external-vw",
+				},
+			},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	vwCfg := kcptestingserver.VirtualWorkspacesConfig(t, server)
+	t.Logf("Waiting for the APIExport virtual workspace URL to point to the external server at %s", vwCfg.Host)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		export, err := kcpClusterClient.Cluster(providerPath).ApisV1alpha2().APIExports().Get(ctx, "external-vw", metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Sprintf("failed to get APIExport: %v", err)
+		}
+		urls := framework.ExportVirtualWorkspaceURLs(export)
+		for _, url := range urls {
+			if strings.HasPrefix(url, vwCfg.Host+"/") {
+				vwCfg.Host = url
+				return true, ""
+			}
+		}
+		return false, fmt.Sprintf("no virtual workspace URL of the external server in %v", urls)
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "APIExport virtual workspace URL does not point to the external server")
+
+	t.Logf("Reaching the bound workspace through the APIExport virtual workspace at %s", vwCfg.Host)
+	vwClusterClient, err := kcpkubernetesclientset.NewForConfig(rest.CopyConfig(vwCfg))
+	require.NoError(t, err)
+	kcptestinghelpers.Eventually(t, func() (bool, string) {
+		if _, err := vwClusterClient.Cluster(consumerClusterName.Path()).Discovery().ServerGroups(); err != nil {
+			return false, fmt.Sprintf("failed to get discovery through the virtual workspace: %v", err)
+		}
+		return true, ""
+	}, wait.ForeverTestTimeout, 100*time.Millisecond, "virtual workspace of the external server is not reachable")
+}
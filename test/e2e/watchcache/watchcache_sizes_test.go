@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchcache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcpkubernetesclientset "github.com/kcp-dev/client-go/kubernetes"
+
+	"github.com/kcp-dev/kcp/sdk/apis/core"
+	kcptesting "github.com/kcp-dev/kcp/sdk/testing"
+	kcptestingserver "github.com/kcp-dev/kcp/sdk/testing/server"
+	"github.com/kcp-dev/kcp/test/e2e/framework"
+)
+
+func TestWatchCacheSizes(t *testing.T) {
+	t.Parallel()
+	framework.Suite(t, "control-plane")
+
+	// Disable the watch cache for configmaps only, secrets keep the default.
+	server := kcptesting.PrivateKcpServer(t, kcptestingserver.WithWatchCacheSizes(map[string]int{"configmaps": 0}))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	wsPath, _ := kcptesting.NewWorkspaceFixture(t, server, core.RootCluster.Path(), kcptesting.WithRootShard())
+	kubeClusterClient, err := kcpkubernetesclientset.NewForConfig(server.BaseConfig(t))
+	require.NoError(t, err)
+
+	t.Logf("Establishing watches on configmaps and secrets in %q", wsPath)
+	for i := 0; i < 10; i++ {
+		cmWatch, err := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("default").Watch(ctx, metav1.ListOptions{})
+		require.NoError(t, err, "failed to watch configmaps")
+		t.Cleanup(cmWatch.Stop)
+		secretWatch, err := kubeClusterClient.Cluster(wsPath).CoreV1().Secrets("default").Watch(ctx, metav1.ListOptions{})
+		require.NoError(t, err, "failed to watch secrets")
+		t.Cleanup(secretWatch.Stop)
+	}
+
+	_, err = kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("default").Create(ctx, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "uncached"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = kubeClusterClient.Cluster(wsPath).CoreV1().Secrets("default").Create(ctx, &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "cached"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	assertWatchCacheIsPrimed(t, func() error {
+		res, err := kubeClusterClient.Cluster(wsPath).CoreV1().Secrets("default").List(ctx, metav1.ListOptions{ResourceVersion: "0"})
+		if err != nil {
+			return err
+		}
+		if len(res.Items) == 0 {
+			return fmt.Errorf("cache hasn't been primed for secrets yet")
+		}
+		return nil
+	})
+
+	t.Logf("Listing configmaps and secrets 10 times with resourceVersion=0")
+	for i := 0; i < 10; i++ {
+		_, err := kubeClusterClient.Cluster(wsPath).CoreV1().ConfigMaps("default").List(ctx, metav1.ListOptions{ResourceVersion: "0"})
+		require.NoError(t, err)
+		_, err = kubeClusterClient.Cluster(wsPath).CoreV1().Secrets("default").List(ctx, metav1.ListOptions{ResourceVersion: "0"})
+		require.NoError(t, err)
+	}
+
+	_, secretsCacheHit := collectCacheHitsFor(ctx, t, server.RootShardSystemMasterBaseConfig(t), "/core/secrets")
+	require.GreaterOrEqual(t, secretsCacheHit, 10, "expected core.secrets to be served from the watch cache")
+	_, configMapsCacheHit := collectCacheHitsFor(ctx, t, server.RootShardSystemMasterBaseConfig(t), "/core/configmaps")
+	require.Zero(t, configMapsCacheHit, "expected core.configmaps to bypass the disabled watch cache")
+}